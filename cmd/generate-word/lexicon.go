@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanlexicon"
+)
+
+type lexiconCommand struct {
+	Add       lexiconAddCommand       `command:"add" description:"Add a word along with its meaning"`
+	Coin      lexiconCoinCommand      `command:"coin" description:"Generate a word that is not in the lexicon and add it with the given meaning"`
+	Lookup    lexiconLookupCommand    `command:"lookup" description:"Show the meaning of a word"`
+	Translate lexiconTranslateCommand `command:"translate" description:"Show the words with the given meaning"`
+	Search    lexiconSearchCommand    `command:"search" description:"Show the words containing the query in any field, or every word without query"`
+	Remove    lexiconRemoveCommand    `command:"remove" description:"Remove a word"`
+}
+
+// lexiconFile is the flag of the file of the lexicon shared by every lexicon command
+type lexiconFile struct {
+	File string `short:"f" long:"file" default:"lexicon.json" description:"JSON file of the lexicon, created when missing"`
+}
+
+func (f lexiconFile) load() (*aslanlexicon.Lexicon, error) {
+	lexicon, err := aslanlexicon.Load(f.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return aslanlexicon.New(), nil
+	}
+	return lexicon, err
+}
+
+type entryFlags struct {
+	PartOfSpeech string `long:"pos" description:"Part of speech of the word, like noun or verb"`
+	Notes        string `long:"notes" description:"Notes about the word"`
+}
+
+type lexiconAddCommand struct {
+	lexiconFile
+	entryFlags
+	Args struct {
+		Word  string `positional-arg-name:"word"`
+		Gloss string `positional-arg-name:"gloss"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *lexiconAddCommand) Execute(_ []string) error {
+	lexicon, err := c.load()
+	if err != nil {
+		return err
+	}
+	entry := aslanlexicon.Entry{Word: c.Args.Word, Gloss: c.Args.Gloss, PartOfSpeech: c.PartOfSpeech, Notes: c.Notes}
+	if err := lexicon.Add(entry); err != nil {
+		return err
+	}
+	return lexicon.Save(c.File)
+}
+
+type lexiconCoinCommand struct {
+	lexiconFile
+	entryFlags
+	Seed *uint64 `long:"seed" description:"Seed to generate the word with, a random one when missing"`
+	Args struct {
+		Gloss string `positional-arg-name:"gloss"`
+	} `positional-args:"yes" required:"yes"`
+
+	// root holds the flags to generate words with
+	root *commandOptions
+}
+
+func (c *lexiconCoinCommand) Execute(_ []string) error {
+	lexicon, err := c.load()
+	if err != nil {
+		return err
+	}
+	generatorOptions, err := c.root.generatorOptions()
+	if err != nil {
+		return err
+	}
+	seed := rand.Uint64()
+	if c.Seed != nil {
+		seed = *c.Seed
+	}
+	// the seed actually used is recorded so the word can be generated again from its provenance
+	word, seed, err := lexicon.GenerateFromSeed(context.Background(), seed, generatorOptions...)
+	if err != nil {
+		return err
+	}
+	entry := aslanlexicon.Entry{
+		Word:         word,
+		Gloss:        c.Args.Gloss,
+		PartOfSpeech: c.PartOfSpeech,
+		Notes:        c.Notes,
+		Provenance:   &aslanlexicon.Provenance{Seed: &seed, Options: c.root.description()},
+	}
+	if err := lexicon.Add(entry); err != nil {
+		return err
+	}
+	if err := lexicon.Save(c.File); err != nil {
+		return err
+	}
+	fmt.Println(word)
+	return nil
+}
+
+type lexiconLookupCommand struct {
+	lexiconFile
+	Args struct {
+		Word string `positional-arg-name:"word"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *lexiconLookupCommand) Execute(_ []string) error {
+	lexicon, err := c.load()
+	if err != nil {
+		return err
+	}
+	entry, found := lexicon.Lookup(c.Args.Word)
+	if !found {
+		return fmt.Errorf("word %s is not in the lexicon", c.Args.Word)
+	}
+	printEntries(entry)
+	return nil
+}
+
+type lexiconTranslateCommand struct {
+	lexiconFile
+	Args struct {
+		Gloss string `positional-arg-name:"gloss"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *lexiconTranslateCommand) Execute(_ []string) error {
+	lexicon, err := c.load()
+	if err != nil {
+		return err
+	}
+	printEntries(lexicon.Translate(c.Args.Gloss)...)
+	return nil
+}
+
+type lexiconSearchCommand struct {
+	lexiconFile
+	Args struct {
+		Query string `positional-arg-name:"query"`
+	} `positional-args:"yes"`
+}
+
+func (c *lexiconSearchCommand) Execute(_ []string) error {
+	lexicon, err := c.load()
+	if err != nil {
+		return err
+	}
+	printEntries(lexicon.Search(c.Args.Query)...)
+	return nil
+}
+
+type lexiconRemoveCommand struct {
+	lexiconFile
+	Args struct {
+		Word string `positional-arg-name:"word"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (c *lexiconRemoveCommand) Execute(_ []string) error {
+	lexicon, err := c.load()
+	if err != nil {
+		return err
+	}
+	if !lexicon.Remove(c.Args.Word) {
+		return fmt.Errorf("word %s is not in the lexicon", c.Args.Word)
+	}
+	return lexicon.Save(c.File)
+}
+
+func printEntries(entries ...aslanlexicon.Entry) {
+	for _, entry := range entries {
+		if entry.Notes == "" {
+			fmt.Println(entry)
+		} else {
+			fmt.Printf("%s. %s\n", entry, entry.Notes)
+		}
+	}
+}
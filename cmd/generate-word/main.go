@@ -10,10 +10,19 @@ import (
 )
 
 func main() {
-	opts := readOptionsOrFail()
+	opts, commandExecuted := readOptionsOrFail()
+	if commandExecuted {
+		return
+	}
+
+	generatorOptions, err := opts.generatorOptions()
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
 	ctx := context.Background()
-	word, err := aslanwords.Generate(ctx, aslanwords.WithNumberOfSyllables(opts.NumberOfSyllables))
+	word, err := aslanwords.Generate(ctx, generatorOptions...)
 	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -21,3 +30,19 @@ func main() {
 
 	fmt.Println(word)
 }
+
+func registerCategories(path string) error {
+	if path == "" {
+		return nil
+	}
+	categories, err := aslanwords.LoadCategories(path)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if err := aslanwords.RegisterCategory(category); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,8 +3,12 @@ package main
 import (
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/carloscasalar/aslan-words/pkg/aslanlexicon"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,3 +36,65 @@ func Test_cmd_should_generate_a_word_when_called_with_n3(t *testing.T) {
 	require.NoError(t, err, "command execution failed with error: %v, output: %s", err, output)
 	assert.Greater(t, len(output), 0, "expected non-empty output, got: %s", output)
 }
+
+func Test_cmd_should_generate_a_word_of_the_category_when_called_with_c(t *testing.T) {
+	output := runMain(t, "-c", "corporation")
+
+	assert.Regexp(t, "^[A-Z']+\n$", output)
+}
+
+func Test_cmd_lexicon_should_add_coined_words_to_the_lexicon_file(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lexicon.json")
+
+	coined := runMain(t, "lexicon", "coin", "-f", file, "--pos", "noun", "--seed", "7", "star")
+	_ = runMain(t, "lexicon", "add", "-f", file, "ftahr", "honour")
+	found := runMain(t, "lexicon", "translate", "-f", file, "star")
+
+	assert.Equal(t, strings.TrimSpace(coined)+" (noun): star\n", found)
+	assert.Equal(t, "ftahr: honour\n", runMain(t, "lexicon", "lookup", "-f", file, "Ftahr"))
+
+	_ = runMain(t, "lexicon", "remove", "-f", file, "ftahr")
+	assert.Equal(t, found, runMain(t, "lexicon", "search", "-f", file))
+}
+
+func Test_cmd_lexicon_coin_should_record_the_seed_that_generates_the_word(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lexicon.json")
+
+	star := runMain(t, "lexicon", "coin", "-f", file, "--seed", "7", "star")
+	moon := runMain(t, "lexicon", "coin", "-f", file, "--seed", "7", "moon")
+	lexicon, err := aslanlexicon.Load(file)
+	require.NoError(t, err)
+	entry, found := lexicon.Lookup(strings.TrimSpace(moon))
+	require.True(t, found)
+
+	assert.NotEqual(t, star, moon)
+	require.NotNil(t, entry.Provenance)
+	require.NotNil(t, entry.Provenance.Seed)
+	again := runMain(t, "lexicon", "coin", "-f", filepath.Join(t.TempDir(), "lexicon.json"), "--seed", strconv.FormatUint(*entry.Provenance.Seed, 10), "moon")
+	assert.Equal(t, moon, again)
+}
+
+// runMain runs the command with the given arguments returning what it writes to stdout
+func runMain(t *testing.T, args ...string) string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	os.Args = append([]string{"path-to-cmd"}, args...)
+	main()
+
+	w.Close()
+	os.Stdout = old
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(output)
+}
+
+func Test_cmd_text_should_generate_the_paragraphs_of_text(t *testing.T) {
+	output := runMain(t, "-p", "vilani", "text", "--paragraphs", "2", "--seed", "5")
+
+	paragraphs := strings.Split(strings.TrimSpace(output), "\n\n")
+	assert.Len(t, paragraphs, 2)
+	assert.Equal(t, output, runMain(t, "-p", "vilani", "text", "--paragraphs", "2", "--seed", "5"))
+}
@@ -2,22 +2,55 @@ package main
 
 import (
 	"os"
+	"strconv"
 
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
 	"github.com/jessevdk/go-flags"
 )
 
 type commandOptions struct {
-	NumberOfSyllables int `short:"s" default:"2" long:"number-of-syllables" description:"Number of syllables of the aslan word to generate"`
+	NumberOfSyllables int    `short:"s" default:"2" long:"number-of-syllables" description:"Number of syllables of the aslan word to generate"`
+	Profile           string `short:"p" default:"aslan" long:"profile" description:"Language of the word: aslan, vargr, vilani or zhodani"`
+	Category          string `short:"c" long:"category" description:"Kind of entity the word names: ship, world, clan, person, corporation or one of the categories file. Its syllables, language and style replace the other flags"`
+	CategoriesFile    string `long:"categories-file" description:"JSON or YAML file with homebrew categories to register"`
+
+	Lexicon lexiconCommand `command:"lexicon" description:"Manage a lexicon of words and their meanings"`
+	Text    textCommand    `command:"text" description:"Generate paragraphs of Aslan text with the profile and category flags, the number of syllables is ignored"`
+}
+
+// generatorOptions returns the options to generate words with the flags, registering the categories of the file if any
+func (o *commandOptions) generatorOptions() ([]aslanwords.GeneratorOption, error) {
+	if err := registerCategories(o.CategoriesFile); err != nil {
+		return nil, err
+	}
+	generatorOptions := []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(o.NumberOfSyllables), aslanwords.WithProfile(o.Profile)}
+	if o.Category != "" {
+		generatorOptions = append(generatorOptions, aslanwords.WithCategory(o.Category))
+	}
+	return generatorOptions, nil
+}
+
+// description returns the flags words are generated with, to tell how a word was generated
+func (o *commandOptions) description() []string {
+	description := []string{"number-of-syllables=" + strconv.Itoa(o.NumberOfSyllables), "profile=" + o.Profile}
+	if o.Category != "" {
+		description = append(description, "category="+o.Category)
+	}
+	return description
 }
 
-func readOptionsOrFail() commandOptions {
+// readOptionsOrFail parses the command line, telling whether it was a command that has already been executed
+func readOptionsOrFail() (commandOptions, bool) {
 	var opts commandOptions
+	opts.Lexicon.Coin.root = &opts
+	opts.Text.root = &opts
 	parser := flags.NewParser(&opts, flags.Default)
+	parser.SubcommandsOptional = true
 	if _, err := parser.Parse(); err != nil {
 		if flagsErr, ok := err.(flags.ErrorType); ok && flagsErr == flags.ErrHelp {
 			os.Exit(0)
 		}
 		os.Exit(1)
 	}
-	return opts
+	return opts, parser.Active != nil
 }
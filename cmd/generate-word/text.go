@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslantext"
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+type textCommand struct {
+	Paragraphs   int     `long:"paragraphs" default:"1" description:"Number of paragraphs"`
+	MinSentences int     `long:"min-sentences" default:"3" description:"Minimum number of sentences of each paragraph"`
+	MaxSentences int     `long:"max-sentences" default:"6" description:"Maximum number of sentences of each paragraph"`
+	MinWords     int     `long:"min-words" default:"4" description:"Minimum number of words of each sentence"`
+	MaxWords     int     `long:"max-words" default:"12" description:"Maximum number of words of each sentence"`
+	Seed         *uint64 `long:"seed" description:"Seed to generate the same text every time"`
+
+	// root holds the flags to generate words with
+	root *commandOptions
+}
+
+func (c *textCommand) Execute(_ []string) error {
+	if err := registerCategories(c.root.CategoriesFile); err != nil {
+		return err
+	}
+	wordOptions := []aslanwords.GeneratorOption{aslanwords.WithProfile(c.root.Profile)}
+	if c.root.Category != "" {
+		wordOptions = append(wordOptions, aslanwords.WithCategory(c.root.Category))
+	}
+	opts := []aslantext.Option{
+		aslantext.WithParagraphs(c.Paragraphs),
+		aslantext.WithSentencesPerParagraph(c.MinSentences, c.MaxSentences),
+		aslantext.WithWordsPerSentence(c.MinWords, c.MaxWords),
+		aslantext.WithWordOptions(wordOptions...),
+	}
+	if c.Seed != nil {
+		opts = append(opts, aslantext.WithSeed(*c.Seed))
+	}
+
+	text, err := aslantext.Generate(context.Background(), opts...)
+	if err != nil {
+		return err
+	}
+	fmt.Println(text)
+	return nil
+}
@@ -39,6 +39,15 @@ func (td TemplateDefinition) SyllableKeySequence() []string {
 	return sequence
 }
 
+// SlotSequence returns the slots of every syllable in the template
+func (td TemplateDefinition) SlotSequence() [][]Slot {
+	sequence := make([][]Slot, len(td))
+	for i, sd := range td {
+		sequence[i] = sd.Slots()
+	}
+	return sequence
+}
+
 // GenerateTemplate generates a template with the given number of syllables for an Aslan word
 // built with the rules of https://github.com/s0rg/fantasyname?tab=readme-ov-file#pattern-syntax
 // and the Aslan language rules
@@ -54,26 +63,66 @@ func GenerateTemplate(numberOfSyllables int, opts ...TemplateOption) TemplateDef
 type syllableSequenceBuilder struct {
 	generateRandomIntegerUpTo    GenerateRandomIntegerUpToFn
 	vowelTemplateChanceGenerator GenerateRandomIntegerUpToFn
-	lastSyllableGenerated        syllableDefinition
+	firstSyllableKeys            []string
+	lastSyllableKeys             []string
+	leadingSyllables             []SyllableRestriction
+	trailingSyllables            []SyllableRestriction
+	rules                        *Rules
 }
 
 func newSyllableSequenceBuilder(opt *templateOptions) *syllableSequenceBuilder {
-	return &syllableSequenceBuilder{generateRandomIntegerUpTo: opt.syllableChanceGenerator, vowelTemplateChanceGenerator: opt.vowelTemplateChanceGenerator}
+	return &syllableSequenceBuilder{
+		generateRandomIntegerUpTo:    opt.syllableChanceGenerator,
+		vowelTemplateChanceGenerator: opt.vowelTemplateChanceGenerator,
+		firstSyllableKeys:            opt.firstSyllableKeys,
+		lastSyllableKeys:             opt.lastSyllableKeys,
+		leadingSyllables:             opt.leadingSyllables,
+		trailingSyllables:            opt.trailingSyllables,
+		rules:                        opt.rules,
+	}
 }
 
 func (b *syllableSequenceBuilder) randomSyllableSequence(numberOfSyllables int, previousSyllables ...syllableDefinition) []syllableDefinition {
 	if numberOfSyllables < 1 {
 		return previousSyllables
 	}
-	if len(previousSyllables) == 0 {
-		return b.randomSyllableSequence(numberOfSyllables-1, b.pickRandomSyllable(allSyllables()))
+	index := len(previousSyllables)
+	var candidates []syllableDefinition
+	if index == 0 {
+		candidates = restrictToKeys(b.rules.allSyllables(), b.firstSyllableKeys)
+	} else {
+		candidates = previousSyllables[index-1].SyllablesThatCanFollowThis()
+	}
+	if numberOfSyllables == 1 {
+		candidates = restrictToKeys(candidates, b.lastSyllableKeys)
+	}
+	restriction := b.restrictionAt(index, index+numberOfSyllables)
+	if restriction != nil {
+		candidates = restrictToKeys(candidates, []string{restriction.Key})
+	}
+	nextSyllable := b.pickRandomSyllable(candidates)
+	nextSyllable.PlaceAt(index, index+numberOfSyllables)
+	if restriction != nil {
+		nextSyllable.RestrictSlots(restriction.Options)
+	}
+	if index > 0 {
+		previousSyllables[index-1].EnforceNoConsecutiveSingleVowels(nextSyllable, b.vowelTemplateChanceGenerator)
 	}
-	lastSyllable := previousSyllables[len(previousSyllables)-1]
-	nextSyllable := b.pickRandomSyllable(lastSyllable.SyllablesThatCanFollowThis())
-	lastSyllable.EnforceNoConsecutiveSingleVowels(nextSyllable, b.vowelTemplateChanceGenerator)
 	return b.randomSyllableSequence(numberOfSyllables-1, append(previousSyllables, nextSyllable)...)
 }
 
+// restrictionAt returns the restriction of the syllable at the index of a word with the given number of syllables, if any.
+// Leading restrictions take precedence over trailing ones.
+func (b *syllableSequenceBuilder) restrictionAt(index, numberOfSyllables int) *SyllableRestriction {
+	if index < len(b.leadingSyllables) {
+		return &b.leadingSyllables[index]
+	}
+	if trailing := index - numberOfSyllables + len(b.trailingSyllables); trailing >= 0 {
+		return &b.trailingSyllables[trailing]
+	}
+	return nil
+}
+
 func (b *syllableSequenceBuilder) pickRandomSyllable(definitions []syllableDefinition) syllableDefinition {
 	totalWeight := 0
 	for _, def := range definitions {
@@ -89,6 +138,30 @@ func (b *syllableSequenceBuilder) pickRandomSyllable(definitions []syllableDefin
 	return definitions[len(definitions)-1]
 }
 
+// restrictToKeys returns the definitions with any of the keys that can be picked. When no key is given, or none of the definitions
+// with any of them can be picked, the restriction cannot be applied and all the definitions are returned
+func restrictToKeys(definitions []syllableDefinition, keys []string) []syllableDefinition {
+	if len(keys) == 0 {
+		return definitions
+	}
+	var restricted []syllableDefinition
+	for _, def := range definitions {
+		if def.Weight() == 0 {
+			continue
+		}
+		for _, key := range keys {
+			if strings.EqualFold(string(def.Key()), key) {
+				restricted = append(restricted, def)
+				break
+			}
+		}
+	}
+	if len(restricted) == 0 {
+		return definitions
+	}
+	return restricted
+}
+
 // GenerateRandomIntegerUpToFn is a function that is expected to generate a positive integer from zero up to the given number minus one
 type GenerateRandomIntegerUpToFn func(int) int
 
@@ -98,6 +171,11 @@ type TemplateOption func(*templateOptions)
 type templateOptions struct {
 	syllableChanceGenerator      GenerateRandomIntegerUpToFn
 	vowelTemplateChanceGenerator GenerateRandomIntegerUpToFn
+	firstSyllableKeys            []string
+	lastSyllableKeys             []string
+	leadingSyllables             []SyllableRestriction
+	trailingSyllables            []SyllableRestriction
+	rules                        *Rules
 }
 
 // WithSyllableChanceGenerator sets the random number generator to choose the syllable using its weight over all the possible syllables
@@ -114,10 +192,60 @@ func WithVowelTemplateChanceGenerator(fn GenerateRandomIntegerUpToFn) TemplateOp
 	}
 }
 
+// WithRules sets the rules used to build the syllables, by default the ones of the Aslan language. A nil value keeps the default.
+func WithRules(rules *Rules) TemplateOption {
+	return func(o *templateOptions) {
+		if rules != nil {
+			o.rules = rules
+		}
+	}
+}
+
+// WithFirstSyllableIn restricts the first syllable to the ones with any of the given keys, as returned by TemplateDefinition.SyllableKeySequence
+func WithFirstSyllableIn(keys ...string) TemplateOption {
+	return func(o *templateOptions) {
+		o.firstSyllableKeys = keys
+	}
+}
+
+// WithLastSyllableIn restricts the last syllable to the ones with any of the given keys, as returned by TemplateDefinition.SyllableKeySequence.
+// It is ignored when none of the syllables that can follow the previous one has any of the keys.
+func WithLastSyllableIn(keys ...string) TemplateOption {
+	return func(o *templateOptions) {
+		o.lastSyllableKeys = keys
+	}
+}
+
+// SyllableRestriction restricts a syllable of the word to a kind and its slots to some of their options
+type SyllableRestriction struct {
+	// Key is the kind of the syllable, as returned by TemplateDefinition.SyllableKeySequence
+	Key string
+	// Options are the letters each slot of the syllable can be made of, in the order of its slots.
+	// A slot without options, or whose template has none of them, keeps all of its options.
+	Options [][]string
+}
+
+// WithLeadingSyllables restricts the first syllables of the word, one restriction for each of them in order.
+// Like WithLastSyllableIn, the key of a restriction is ignored when none of the syllables that can follow the previous one has it.
+func WithLeadingSyllables(restrictions ...SyllableRestriction) TemplateOption {
+	return func(o *templateOptions) {
+		o.leadingSyllables = restrictions
+	}
+}
+
+// WithTrailingSyllables restricts the last syllables of the word, one restriction for each of them in order.
+// Syllables restricted by WithLeadingSyllables too keep the leading restriction.
+func WithTrailingSyllables(restrictions ...SyllableRestriction) TemplateOption {
+	return func(o *templateOptions) {
+		o.trailingSyllables = restrictions
+	}
+}
+
 func applyTemplateOptions(opts ...TemplateOption) *templateOptions {
 	opt := &templateOptions{
 		syllableChanceGenerator:      rand.IntN,
 		vowelTemplateChanceGenerator: rand.IntN,
+		rules:                        aslanRules,
 	}
 	for _, o := range opts {
 		o(opt)
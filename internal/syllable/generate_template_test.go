@@ -2,6 +2,8 @@ package syllable_test
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
@@ -144,6 +146,17 @@ func countSyllableKey(templates []syllable.TemplateDefinition, key string) int {
 	return count
 }
 
+// optionsOf returns the different options of a slot template
+func optionsOf(slotTemplate string) []string {
+	var options []string
+	for _, match := range regexp.MustCompile(`\(([^()]*)\)`).FindAllStringSubmatch(slotTemplate, -1) {
+		if !slices.Contains(options, match[1]) {
+			options = append(options, match[1])
+		}
+	}
+	return options
+}
+
 func chanceGeneratorThatWillGenerate(t *testing.T, sequence ...int) syllable.GenerateRandomIntegerUpToFn {
 	var i int
 	return func(n int) int {
@@ -153,3 +166,150 @@ func chanceGeneratorThatWillGenerate(t *testing.T, sequence ...int) syllable.Gen
 		return chance
 	}
 }
+
+func TestGenerateTemplate_slot_sequence(t *testing.T) {
+	const (
+		vChance   = 0
+		cvChance  = 3
+		vcChance  = 6
+		cvcChance = 8
+	)
+	testCases := map[string]struct {
+		syllableChance    int
+		expectedSlotKinds []syllable.SlotKind
+	}{
+		"of a V syllable should be a vowel":                               {vChance, []syllable.SlotKind{syllable.VowelSlot}},
+		"of a CV syllable should be first consonant and vowel":            {cvChance, []syllable.SlotKind{syllable.FirstConsonantSlot, syllable.VowelSlot}},
+		"of a VC syllable should be vowel and last consonant":             {vcChance, []syllable.SlotKind{syllable.VowelSlot, syllable.LastConsonantSlot}},
+		"of a CVC syllable should be first consonant, vowel and last one": {cvcChance, []syllable.SlotKind{syllable.FirstConsonantSlot, syllable.VowelSlot, syllable.LastConsonantSlot}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Given
+			syllableChancesGenerator := chanceGeneratorThatWillGenerate(t, tc.syllableChance)
+
+			// When
+			template := syllable.GenerateTemplate(1, syllable.WithSyllableChanceGenerator(syllableChancesGenerator))
+
+			// Then
+			require.Len(t, template.SlotSequence(), 1)
+			slots := template.SlotSequence()[0]
+			slotKinds := make([]syllable.SlotKind, len(slots))
+			templateOfSlots := ""
+			for i, slot := range slots {
+				slotKinds[i] = slot.Kind
+				templateOfSlots += slot.Template
+			}
+			assert.Equal(t, tc.expectedSlotKinds, slotKinds)
+			assert.Equal(t, template.TemplateSequence()[0], templateOfSlots)
+		})
+	}
+}
+
+func TestGenerateTemplate_when_first_syllable_is_restricted_it_should_be_one_of_the_given_keys(t *testing.T) {
+	for range 100 {
+		template := syllable.GenerateTemplate(3, syllable.WithFirstSyllableIn("V", "VC"))
+
+		require.Len(t, template.SyllableKeySequence(), 3)
+		assert.Contains(t, []string{"V", "VC"}, template.SyllableKeySequence()[0])
+	}
+}
+
+func TestGenerateTemplate_when_last_syllable_is_restricted_it_should_be_one_of_the_given_keys(t *testing.T) {
+	for numberOfSyllables := 1; numberOfSyllables <= 4; numberOfSyllables++ {
+		for range 100 {
+			template := syllable.GenerateTemplate(numberOfSyllables, syllable.WithLastSyllableIn("VC", "CVC"))
+
+			keys := template.SyllableKeySequence()
+			require.Len(t, keys, numberOfSyllables)
+			assert.Contains(t, []string{"VC", "CVC"}, keys[numberOfSyllables-1])
+		}
+	}
+}
+
+func TestGenerateTemplate_when_a_single_syllable_is_restricted_as_first_and_last_it_should_meet_both_restrictions(t *testing.T) {
+	for range 100 {
+		template := syllable.GenerateTemplate(1, syllable.WithFirstSyllableIn("V", "VC"), syllable.WithLastSyllableIn("CV", "VC"))
+
+		assert.Equal(t, []string{"VC"}, template.SyllableKeySequence())
+	}
+}
+
+func TestGenerateTemplate_when_leading_and_trailing_syllables_are_restricted_they_should_meet_the_restrictions(t *testing.T) {
+	for numberOfSyllables := 2; numberOfSyllables <= 4; numberOfSyllables++ {
+		for range 100 {
+			template := syllable.GenerateTemplate(numberOfSyllables,
+				syllable.WithLeadingSyllables(syllable.SyllableRestriction{Key: "CVC", Options: [][]string{{"kh"}, {"ao", "ea"}}}),
+				syllable.WithTrailingSyllables(syllable.SyllableRestriction{Key: "V", Options: [][]string{{"ya"}}}),
+			)
+
+			keys := template.SyllableKeySequence()
+			slots := template.SlotSequence()
+			require.Len(t, keys, numberOfSyllables)
+			assert.Equal(t, "CVC", keys[0])
+			assert.ElementsMatch(t, []string{"kh"}, optionsOf(slots[0][0].Template))
+			assert.ElementsMatch(t, []string{"ao", "ea"}, optionsOf(slots[0][1].Template))
+			assert.Greater(t, len(optionsOf(slots[0][2].Template)), 1)
+			assert.Equal(t, "V", keys[numberOfSyllables-1])
+			assert.ElementsMatch(t, []string{"ya"}, optionsOf(slots[numberOfSyllables-1][0].Template))
+		}
+	}
+}
+
+func TestGenerateTemplate_when_rules_are_given_it_should_build_the_syllables_with_them(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.FirstConsonants = []syllable.Phoneme{{Text: "zh", Weight: 1}}
+	phonology.SyllableWeights = map[string]int{"CV": 1, "V": 1}
+	rules, err := syllable.NewRules(phonology)
+	require.NoError(t, err)
+
+	for range 100 {
+		template := syllable.GenerateTemplate(3, syllable.WithRules(rules))
+
+		for i, key := range template.SyllableKeySequence() {
+			assert.Contains(t, []string{"V", "CV"}, key)
+			if key == "CV" {
+				assert.True(t, strings.HasPrefix(template.TemplateSequence()[i], "<(zh)>"))
+			}
+		}
+	}
+}
+
+func TestGenerateTemplate_when_rules_restrict_the_followers_it_should_only_generate_allowed_sequences(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.Followers = map[string][]string{"V": {"CV"}, "CV": {"V"}}
+	phonology.SyllableWeights = map[string]int{"V": 1, "CV": 1}
+	rules, err := syllable.NewRules(phonology)
+	require.NoError(t, err)
+
+	for range 100 {
+		keys := syllable.GenerateTemplate(4, syllable.WithRules(rules)).SyllableKeySequence()
+
+		for i := 1; i < len(keys); i++ {
+			assert.NotEqual(t, keys[i-1], keys[i])
+		}
+	}
+}
+
+func TestGenerateTemplate_when_the_phonology_has_positions_it_should_use_the_consonants_of_the_position_of_each_syllable(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.SyllableWeights = map[string]int{"CVC": 1}
+	phonology.Followers = nil
+	phonology.Positions = syllable.Positions{
+		Initial: syllable.PositionalConsonants{FirstConsonants: []syllable.Phoneme{{Text: "kh", Weight: 1}}},
+		Medial:  syllable.PositionalConsonants{FirstConsonants: []syllable.Phoneme{{Text: "t", Weight: 1}}},
+		Final:   syllable.PositionalConsonants{LastConsonants: []syllable.Phoneme{{Text: "r", Weight: 1}}},
+	}
+	rules, err := syllable.NewRules(phonology)
+	require.NoError(t, err)
+
+	slots := syllable.GenerateTemplate(4, syllable.WithRules(rules)).SlotSequence()
+
+	require.Len(t, slots, 4)
+	assert.Equal(t, "<(kh)>", slots[0][0].Template)
+	assert.Equal(t, "<(t)>", slots[1][0].Template)
+	assert.Equal(t, "<(t)>", slots[2][0].Template)
+	assert.NotEqual(t, "<(r)>", slots[2][2].Template)
+	assert.Equal(t, "<(r)>", slots[3][2].Template)
+}
@@ -0,0 +1,301 @@
+package syllable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxPhonemeWeight is the greatest weight of a phoneme. Phonemes are picked from templates where each one is repeated
+// as many times as its weight, so greater weights would make the templates too slow to build and use.
+const MaxPhonemeWeight = 1000
+
+// Phoneme is a sound a slot of a syllable can be made of along with the weight used to pick it over the other sounds of the slot.
+// The weight goes from one to MaxPhonemeWeight.
+type Phoneme struct {
+	Text   string `json:"text" yaml:"text"`
+	Weight int    `json:"weight" yaml:"weight"`
+}
+
+// Phonology holds the sounds syllables are built with and the weight used to pick each kind of syllable.
+// The order of the sounds matters: the same random choices pick the same sound only if the order is kept.
+type Phonology struct {
+	// FirstConsonants are the consonants a syllable can start with
+	FirstConsonants []Phoneme `json:"firstConsonants" yaml:"firstConsonants"`
+	// Vowels are the vowels of the syllables
+	Vowels []Phoneme `json:"vowels" yaml:"vowels"`
+	// LastConsonants are the consonants a syllable can end with
+	LastConsonants []Phoneme `json:"lastConsonants" yaml:"lastConsonants"`
+	// SyllableWeights is the weight of each kind of syllable: V, CV, VC and CVC. A missing kind is never picked.
+	SyllableWeights map[string]int `json:"syllableWeights" yaml:"syllableWeights"`
+	// Followers are the kinds of syllable that can follow each kind. A kind without followers can be followed by any kind.
+	Followers map[string][]string `json:"followers,omitempty" yaml:"followers,omitempty"`
+	// AvoidRepeatedSingleVowels forbids a syllable ending with a single letter vowel to be followed by a syllable starting with the same vowel
+	AvoidRepeatedSingleVowels bool `json:"avoidRepeatedSingleVowels,omitempty" yaml:"avoidRepeatedSingleVowels,omitempty"`
+	// Positions replaces the consonants of the syllables at the start, in the middle or at the end of the words
+	Positions Positions `json:"positions,omitzero" yaml:"positions,omitempty"`
+}
+
+// Positions holds the consonants used instead of the ones of the phonology depending on where the syllable is in its word,
+// like favouring some clusters at the start of the words or forbidding a consonant at their end. Empty ones keep the consonants of the phonology.
+// A word of a single syllable is both initial and final: it starts with an initial consonant and ends with a final one.
+type Positions struct {
+	// Initial are the consonants of the first syllable of a word
+	Initial PositionalConsonants `json:"initial,omitzero" yaml:"initial,omitempty"`
+	// Medial are the consonants of the syllables that are neither the first nor the last one
+	Medial PositionalConsonants `json:"medial,omitzero" yaml:"medial,omitempty"`
+	// Final are the consonants of the last syllable of a word
+	Final PositionalConsonants `json:"final,omitzero" yaml:"final,omitempty"`
+}
+
+// PositionalConsonants are the consonants the syllables at a position of the word start and end with
+type PositionalConsonants struct {
+	FirstConsonants []Phoneme `json:"firstConsonants,omitempty" yaml:"firstConsonants,omitempty"`
+	LastConsonants  []Phoneme `json:"lastConsonants,omitempty" yaml:"lastConsonants,omitempty"`
+}
+
+func consonantsName(kind SlotKind) string {
+	if kind == FirstConsonantSlot {
+		return "first consonants"
+	}
+	return "last consonants"
+}
+
+func (c PositionalConsonants) of(kind SlotKind) []Phoneme {
+	if kind == FirstConsonantSlot {
+		return c.FirstConsonants
+	}
+	return c.LastConsonants
+}
+
+// consonantsAt returns the consonants of the slot of a syllable depending on whether it is the first and whether it is the last
+// syllable of its word, nil when the ones of the phonology are used. Each consonant is taken from the closest edge of the word
+// with consonants for it: the first one from the initial consonants before the final ones, and the last one the other way round.
+func (p Positions) consonantsAt(kind SlotKind, first, last bool) []Phoneme {
+	edges := []struct {
+		isAt       bool
+		consonants PositionalConsonants
+	}{{first, p.Initial}, {last, p.Final}}
+	if kind == LastConsonantSlot {
+		edges[0], edges[1] = edges[1], edges[0]
+	}
+	for _, edge := range edges {
+		if phonemes := edge.consonants.of(kind); edge.isAt && len(phonemes) > 0 {
+			return phonemes
+		}
+	}
+	if phonemes := p.Medial.of(kind); !first && !last && len(phonemes) > 0 {
+		return phonemes
+	}
+	return nil
+}
+
+// AslanPhonology returns the sounds of the Aslan language. Every call returns a new copy that can be safely modified.
+func AslanPhonology() Phonology {
+	return Phonology{
+		FirstConsonants: []Phoneme{
+			{Text: "f", Weight: 5},
+			{Text: "ft", Weight: 3},
+			{Text: "h", Weight: 7},
+			{Text: "hf", Weight: 2},
+			{Text: "hk", Weight: 5},
+			{Text: "hl", Weight: 3},
+			{Text: "hr", Weight: 3},
+			{Text: "ht", Weight: 5},
+			{Text: "hw", Weight: 2},
+			{Text: "k", Weight: 7},
+			{Text: "kh", Weight: 6},
+			{Text: "kht", Weight: 4},
+			{Text: "kt", Weight: 4},
+			{Text: "l", Weight: 2},
+			{Text: "r", Weight: 4},
+			{Text: "s", Weight: 4},
+			{Text: "st", Weight: 3},
+			{Text: "t", Weight: 8},
+			{Text: "tl", Weight: 2},
+			{Text: "tr", Weight: 2},
+			{Text: "w", Weight: 6},
+		},
+		Vowels: []Phoneme{
+			{Text: "a", Weight: 10},
+			{Text: "ai", Weight: 3},
+			{Text: "ao", Weight: 2},
+			{Text: "au", Weight: 1},
+			{Text: "e", Weight: 6},
+			{Text: "ea", Weight: 6},
+			{Text: "ei", Weight: 2},
+			{Text: "i", Weight: 4},
+			{Text: "iy", Weight: 3},
+			{Text: "o", Weight: 2},
+			{Text: "oa", Weight: 1},
+			{Text: "oi", Weight: 2},
+			{Text: "ou", Weight: 1},
+			{Text: "u", Weight: 1},
+			{Text: "ua", Weight: 1},
+			{Text: "ui", Weight: 1},
+			{Text: "ya", Weight: 1},
+			{Text: "yu", Weight: 1},
+		},
+		LastConsonants: []Phoneme{
+			{Text: "h", Weight: 10},
+			{Text: "kh", Weight: 4},
+			{Text: "l", Weight: 3},
+			{Text: "lr", Weight: 4},
+			{Text: "r", Weight: 5},
+			{Text: "rl", Weight: 4},
+			{Text: "s", Weight: 5},
+			{Text: "w", Weight: 6},
+			{Text: "'", Weight: 3},
+		},
+		SyllableWeights: map[string]int{
+			"V":   3,
+			"CV":  3,
+			"VC":  2,
+			"CVC": 2,
+		},
+		Followers: map[string][]string{
+			"VC":  {"V", "VC"},
+			"CVC": {"V", "VC"},
+		},
+		AvoidRepeatedSingleVowels: true,
+	}
+}
+
+// Validate checks the phonology can be used to build syllables, returning an error if not
+func (p Phonology) Validate() error {
+	slots := []struct {
+		name     string
+		phonemes []Phoneme
+	}{
+		{"first consonants", p.FirstConsonants},
+		{"vowels", p.Vowels},
+		{"last consonants", p.LastConsonants},
+	}
+	for _, slot := range slots {
+		if err := validatePhonemes(slot.phonemes); err != nil {
+			return fmt.Errorf("invalid %s: %w", slot.name, err)
+		}
+	}
+	if singles := singleVowelsOf(p.Vowels); p.AvoidRepeatedSingleVowels && len(p.Vowels) == 1 && len(singles) == 1 {
+		return fmt.Errorf("invalid vowels: there must be another vowel besides %q to avoid repeated single vowels", singles[0])
+	}
+	positions := []struct {
+		name       string
+		consonants PositionalConsonants
+	}{
+		{"initial", p.Positions.Initial},
+		{"medial", p.Positions.Medial},
+		{"final", p.Positions.Final},
+	}
+	for _, position := range positions {
+		for _, kind := range []SlotKind{FirstConsonantSlot, LastConsonantSlot} {
+			phonemes := position.consonants.of(kind)
+			if len(phonemes) == 0 {
+				continue
+			}
+			if err := validatePhonemes(phonemes); err != nil {
+				return fmt.Errorf("invalid %s %s: %w", position.name, consonantsName(kind), err)
+			}
+		}
+	}
+
+	for key, weight := range p.SyllableWeights {
+		if !isKnownKey(key) {
+			return fmt.Errorf("unknown kind of syllable %q, it must be one of V, CV, VC or CVC", key)
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight of syllable %s cannot be negative", key)
+		}
+	}
+	for key, followers := range p.Followers {
+		if !isKnownKey(key) {
+			return fmt.Errorf("unknown kind of syllable %q, it must be one of V, CV, VC or CVC", key)
+		}
+		for _, follower := range followers {
+			if !isKnownKey(follower) {
+				return fmt.Errorf("unknown kind of syllable %q following %s, it must be one of V, CV, VC or CVC", follower, key)
+			}
+		}
+	}
+
+	anyWeight := false
+	for _, key := range allKeys {
+		if p.syllableWeight(key) == 0 {
+			continue
+		}
+		anyWeight = true
+		followersWeight := 0
+		for _, follower := range p.followersOf(key) {
+			followersWeight += p.syllableWeight(follower)
+		}
+		if followersWeight == 0 {
+			return fmt.Errorf("syllable %s must be followed by at least one kind of syllable with weight", strings.ToUpper(string(key)))
+		}
+	}
+	if !anyWeight {
+		return errors.New("at least one kind of syllable must have weight")
+	}
+	return nil
+}
+
+// followersOf returns the kinds of syllable that can follow the given kind
+func (p Phonology) followersOf(key syllableKey) []syllableKey {
+	var followers []string
+	for k, f := range p.Followers {
+		if strings.EqualFold(k, string(key)) {
+			followers = f
+		}
+	}
+	if followers == nil {
+		return allKeys
+	}
+	var keys []syllableKey
+	for _, known := range allKeys {
+		for _, follower := range followers {
+			if strings.EqualFold(follower, string(known)) {
+				keys = append(keys, known)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+func (p Phonology) syllableWeight(key syllableKey) int {
+	for k, weight := range p.SyllableWeights {
+		if strings.EqualFold(k, string(key)) {
+			return weight
+		}
+	}
+	return 0
+}
+
+func validatePhonemes(phonemes []Phoneme) error {
+	if len(phonemes) == 0 {
+		return errors.New("there must be at least one phoneme")
+	}
+	for _, phoneme := range phonemes {
+		if phoneme.Text == "" {
+			return errors.New("phonemes cannot be empty")
+		}
+		if strings.ContainsAny(phoneme.Text, "<>()|!~") {
+			return fmt.Errorf("phoneme %q cannot contain any of <>()|!~", phoneme.Text)
+		}
+		if phoneme.Weight < 1 {
+			return fmt.Errorf("weight of phoneme %q must be one or greater", phoneme.Text)
+		}
+		if phoneme.Weight > MaxPhonemeWeight {
+			return fmt.Errorf("weight of phoneme %q cannot be greater than %d", phoneme.Text, MaxPhonemeWeight)
+		}
+	}
+	return nil
+}
+
+func isKnownKey(key string) bool {
+	for _, known := range allKeys {
+		if strings.EqualFold(key, string(known)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package syllable_test
+
+import (
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAslanPhonology_should_be_valid(t *testing.T) {
+	assert.NoError(t, syllable.AslanPhonology().Validate())
+}
+
+func TestPhonology_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		modify        func(p *syllable.Phonology)
+		expectedError string
+	}{
+		"without vowels": {
+			modify:        func(p *syllable.Phonology) { p.Vowels = nil },
+			expectedError: "invalid vowels: there must be at least one phoneme",
+		},
+		"with an empty consonant": {
+			modify:        func(p *syllable.Phonology) { p.FirstConsonants[0].Text = "" },
+			expectedError: "invalid first consonants: phonemes cannot be empty",
+		},
+		"with a consonant using template syntax": {
+			modify:        func(p *syllable.Phonology) { p.LastConsonants[0].Text = "h|k" },
+			expectedError: `invalid last consonants: phoneme "h|k" cannot contain any of <>()|!~`,
+		},
+		"with a phoneme without weight": {
+			modify:        func(p *syllable.Phonology) { p.Vowels[0].Weight = 0 },
+			expectedError: `invalid vowels: weight of phoneme "a" must be one or greater`,
+		},
+		"with a phoneme weight too great": {
+			modify:        func(p *syllable.Phonology) { p.Vowels[0].Weight = syllable.MaxPhonemeWeight + 1 },
+			expectedError: `invalid vowels: weight of phoneme "a" cannot be greater than 1000`,
+		},
+		"with a single vowel when repeated single vowels are avoided": {
+			modify:        func(p *syllable.Phonology) { p.Vowels = []syllable.Phoneme{{Text: "a", Weight: 1}} },
+			expectedError: `invalid vowels: there must be another vowel besides "a" to avoid repeated single vowels`,
+		},
+		"with an unknown kind of syllable": {
+			modify:        func(p *syllable.Phonology) { p.SyllableWeights["CCV"] = 1 },
+			expectedError: `unknown kind of syllable "CCV", it must be one of V, CV, VC or CVC`,
+		},
+		"with a negative syllable weight": {
+			modify:        func(p *syllable.Phonology) { p.SyllableWeights["CV"] = -1 },
+			expectedError: "weight of syllable CV cannot be negative",
+		},
+		"when a syllable cannot be followed by any syllable with weight": {
+			modify: func(p *syllable.Phonology) {
+				p.SyllableWeights = map[string]int{"CV": 1, "CVC": 1}
+			},
+			expectedError: "syllable CVC must be followed by at least one kind of syllable with weight",
+		},
+		"without syllables with weight": {
+			modify:        func(p *syllable.Phonology) { p.SyllableWeights = nil },
+			expectedError: "at least one kind of syllable must have weight",
+		},
+		"with an invalid initial consonant": {
+			modify: func(p *syllable.Phonology) {
+				p.Positions.Initial.FirstConsonants = []syllable.Phoneme{{Text: "kh", Weight: 0}}
+			},
+			expectedError: `invalid initial first consonants: weight of phoneme "kh" must be one or greater`,
+		},
+		"with an empty final consonant": {
+			modify: func(p *syllable.Phonology) {
+				p.Positions.Final.LastConsonants = []syllable.Phoneme{{Text: "", Weight: 1}}
+			},
+			expectedError: "invalid final last consonants: phonemes cannot be empty",
+		},
+		"with an unknown follower": {
+			modify:        func(p *syllable.Phonology) { p.Followers["V"] = []string{"VV"} },
+			expectedError: `unknown kind of syllable "VV" following V, it must be one of V, CV, VC or CVC`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			phonology := syllable.AslanPhonology()
+			tc.modify(&phonology)
+
+			assert.EqualError(t, phonology.Validate(), tc.expectedError)
+		})
+	}
+}
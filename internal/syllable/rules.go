@@ -0,0 +1,270 @@
+package syllable
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/s0rg/fantasyname/stringers"
+	"github.com/s0rg/fantasyname/wrappers"
+)
+
+var (
+	templateOptionRegexp = regexp.MustCompile(`\(([^()]*)\)`)
+	aslanRules           = mustNewRules(AslanPhonology())
+)
+
+// Rules are the templates and the rules to build syllables out of a phonology
+type Rules struct {
+	phonology      Phonology
+	firstConsonant template
+	vowel          template
+	lastConsonant  template
+	placed         map[placedSlot]template
+	swaps          map[swapKey]templateSwap
+	allSwaps       []swapKey
+	weights        map[syllableKey]int
+	followers      map[syllableKey][]syllableKey
+}
+
+// NewRules builds the rules to generate-word syllables with the given phonology
+func NewRules(phonology Phonology) (*Rules, error) {
+	if err := phonology.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid phonology: %w", err)
+	}
+	rules := &Rules{
+		phonology:      phonology,
+		firstConsonant: newTemplate(phonology.FirstConsonants),
+		vowel:          newTemplate(phonology.Vowels),
+		lastConsonant:  newTemplate(phonology.LastConsonants),
+		placed:         make(map[placedSlot]template),
+		weights:        make(map[syllableKey]int),
+		followers:      make(map[syllableKey][]syllableKey),
+	}
+	if phonology.AvoidRepeatedSingleVowels {
+		rules.swaps, rules.allSwaps = newSwaps(phonology.Vowels)
+	}
+	for _, slot := range allPlacedSlots() {
+		if phonemes := phonology.Positions.consonantsAt(slot.kind, slot.first, slot.last); phonemes != nil {
+			rules.placed[slot] = newTemplate(phonemes)
+		}
+	}
+	for _, key := range allKeys {
+		rules.weights[key] = phonology.syllableWeight(key)
+		rules.followers[key] = phonology.followersOf(key)
+	}
+	return rules, nil
+}
+
+func mustNewRules(phonology Phonology) *Rules {
+	rules, err := NewRules(phonology)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// AslanRules returns the rules of the Aslan language
+func AslanRules() *Rules {
+	return aslanRules
+}
+
+// Phonology returns the phonology the rules have been built from
+func (r *Rules) Phonology() Phonology {
+	return r.phonology
+}
+
+// FirstConsonants returns the consonants a syllable can start with along with their weight
+func (r *Rules) FirstConsonants() map[string]int {
+	return r.firstConsonant.options()
+}
+
+// Vowels returns the vowels of a syllable along with their weight
+func (r *Rules) Vowels() map[string]int {
+	return r.vowel.options()
+}
+
+// LastConsonants returns the consonants a syllable can end with along with their weight
+func (r *Rules) LastConsonants() map[string]int {
+	return r.lastConsonant.options()
+}
+
+// HasPositions tells whether the consonants of a syllable depend on where it is in its word, see Positions
+func (r *Rules) HasPositions() bool {
+	return len(r.placed) > 0
+}
+
+// ConsonantsAt returns the consonants of the slot, along with their weight, of a syllable depending on whether it is the first
+// and whether it is the last syllable of its word. A word of a single syllable is both. The vowel slot returns the vowels.
+func (r *Rules) ConsonantsAt(kind SlotKind, first, last bool) map[string]int {
+	if kind == VowelSlot {
+		return r.Vowels()
+	}
+	return r.consonantTemplate(kind, first, last).options()
+}
+
+// SingleVowels returns the vowels made of a single letter that cannot be used by two consecutive syllables.
+// It is empty unless the phonology avoids repeated single vowels.
+func (r *Rules) SingleVowels() []string {
+	if !r.phonology.AvoidRepeatedSingleVowels {
+		return nil
+	}
+	return singleVowelsOf(r.phonology.Vowels)
+}
+
+// Shape is a kind of syllable: its key, as returned by TemplateDefinition.SyllableKeySequence, the weight used to pick it and its slots
+type Shape struct {
+	Key    string
+	Weight int
+	Slots  []SlotKind
+}
+
+// Shapes returns every kind of syllable a word can be made of
+func (r *Rules) Shapes() []Shape {
+	definitions := r.allSyllables()
+	shapes := make([]Shape, len(definitions))
+	for i, def := range definitions {
+		shapes[i] = Shape{Key: strings.ToUpper(string(def.Key())), Weight: def.Weight()}
+		for _, slot := range def.Slots() {
+			shapes[i].Slots = append(shapes[i].Slots, slot.Kind)
+		}
+	}
+	return shapes
+}
+
+// VowelSwap holds the vowels, with their weight, that two consecutive syllables can have when the first one ends with vowel
+// and the next one starts with vowel. The syllables after them keep alternating both tables.
+type VowelSwap struct {
+	Vowels     map[string]int
+	NextVowels map[string]int
+}
+
+// VowelSwaps returns all the swaps of vowels used to avoid consecutive single vowels. All of them have the same chance to be picked.
+func (r *Rules) VowelSwaps() []VowelSwap {
+	vowelSwaps := make([]VowelSwap, len(r.allSwaps))
+	for i, key := range r.allSwaps {
+		swap := r.swaps[key]
+		vowelSwaps[i] = VowelSwap{
+			Vowels:     swap.modifiedTemplate.options(),
+			NextVowels: r.swaps[swap.reverseSwapKey].modifiedTemplate.options(),
+		}
+	}
+	return vowelSwaps
+}
+
+// CanBeFollowedBy tells whether a syllable with the given key, as returned by TemplateDefinition.SyllableKeySequence,
+// can be followed by a syllable with the next key
+func (r *Rules) CanBeFollowedBy(key, nextKey string) bool {
+	for _, next := range r.followers[syllableKey(strings.ToLower(key))] {
+		if strings.EqualFold(string(next), nextKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextVowels returns the vowels, along with their weight, a syllable with the next key can have after a syllable with the
+// given key and vowel. When both syllables meet with vowels, only the ones the swaps of vowels allow after the vowel are returned,
+// so a single vowel is never repeated.
+func (r *Rules) NextVowels(key, vowel, nextKey string) map[string]int {
+	previous, next := syllableKey(strings.ToLower(key)), syllableKey(strings.ToLower(nextKey))
+	if previous == "" || next == "" || previous.EndsWithConsonant() || next.StartsWithConsonant() || len(r.allSwaps) == 0 {
+		return r.Vowels()
+	}
+	vowels := make(map[string]int)
+	for _, swap := range r.VowelSwaps() {
+		if _, ok := swap.Vowels[vowel]; ok {
+			maps.Copy(vowels, swap.NextVowels)
+		}
+	}
+	if len(vowels) == 0 {
+		// the vowel is not one of the phonology, so no swap can leave it out
+		return r.Vowels()
+	}
+	return vowels
+}
+
+// Collapses tells whether any letter of the next text would be collapsed when written right after the text, the way
+// fantasyname collapses the letters repeated by the concatenation of syllables
+func (r *Rules) Collapses(text, nextText string) bool {
+	return collapsed(text+nextText) != collapsed(text)+nextText
+}
+
+func collapsed(text string) string {
+	return wrappers.Collapsed(stringers.Literal(text)).String()
+}
+
+// placedSlot is a consonant slot of a syllable that is the first and, or, the last one of its word
+type placedSlot struct {
+	kind  SlotKind
+	first bool
+	last  bool
+}
+
+func allPlacedSlots() []placedSlot {
+	var slots []placedSlot
+	for _, kind := range []SlotKind{FirstConsonantSlot, LastConsonantSlot} {
+		for _, first := range []bool{true, false} {
+			for _, last := range []bool{true, false} {
+				slots = append(slots, placedSlot{kind: kind, first: first, last: last})
+			}
+		}
+	}
+	return slots
+}
+
+// consonantTemplate returns the template of the consonant slot of a syllable depending on where it is in its word
+func (r *Rules) consonantTemplate(kind SlotKind, first, last bool) template {
+	if placed, ok := r.placed[placedSlot{kind: kind, first: first, last: last}]; ok {
+		return placed
+	}
+	if kind == FirstConsonantSlot {
+		return r.firstConsonant
+	}
+	return r.lastConsonant
+}
+
+func (r *Rules) pickRandomSwap(randomIndexPicker GenerateRandomIntegerUpToFn) *templateSwap {
+	chosenSwapIndex := randomIndexPicker(len(r.allSwaps))
+	vowelTemplateSwapKey := r.allSwaps[chosenSwapIndex]
+	swap := r.swaps[vowelTemplateSwapKey]
+	return &swap
+}
+
+func (r *Rules) allSyllables() []syllableDefinition {
+	return r.syllables(allKeys)
+}
+
+// syllables returns new definitions of the syllables with the given keys
+func (r *Rules) syllables(keys []syllableKey) []syllableDefinition {
+	definitions := make([]syllableDefinition, len(keys))
+	for i, key := range keys {
+		definitions[i] = newSyllable(r, key)
+	}
+	return definitions
+}
+
+// options returns the letters of each option of the template with the number of times it appears on it
+func (t template) options() map[string]int {
+	options := make(map[string]int)
+	for _, match := range templateOptionRegexp.FindAllStringSubmatch(string(t), -1) {
+		options[match[1]]++
+	}
+	return options
+}
+
+// restrictedTo returns the template with only the given options, each one as many times as it appears on it.
+// When the template has none of them the restriction cannot be applied and the template is returned as it is.
+func (t template) restrictedTo(options []string) template {
+	var kept []string
+	for _, match := range templateOptionRegexp.FindAllStringSubmatch(string(t), -1) {
+		if contains(options, match[1]) {
+			kept = append(kept, match[0])
+		}
+	}
+	if len(kept) == 0 {
+		return t
+	}
+	return template("<" + strings.Join(kept, "|") + ">")
+}
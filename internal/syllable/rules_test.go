@@ -0,0 +1,216 @@
+package syllable_test
+
+import (
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstConsonants_should_have_the_weight_of_each_consonant(t *testing.T) {
+	consonants := syllable.AslanRules().FirstConsonants()
+
+	assert.Equal(t, 5, consonants["f"])
+	assert.Equal(t, 6, consonants["kh"])
+	assert.NotContains(t, consonants, "")
+}
+
+func TestVowels_should_have_the_weight_of_each_vowel(t *testing.T) {
+	vowels := syllable.AslanRules().Vowels()
+
+	assert.Equal(t, 10, vowels["a"])
+	assert.Equal(t, 1, vowels["yu"])
+	assert.NotContains(t, vowels, "")
+}
+
+func TestLastConsonants_should_have_the_weight_of_each_consonant(t *testing.T) {
+	consonants := syllable.AslanRules().LastConsonants()
+
+	assert.Equal(t, 10, consonants["h"])
+	assert.Equal(t, 3, consonants["'"])
+}
+
+func TestSingleVowels_should_be_the_five_one_letter_vowels(t *testing.T) {
+	assert.ElementsMatch(t, []string{"a", "e", "i", "o", "u"}, syllable.AslanRules().SingleVowels())
+}
+
+func TestCanBeFollowedBy(t *testing.T) {
+	testCases := map[string]struct {
+		key      string
+		nextKey  string
+		expected bool
+	}{
+		"V can be followed by CV":         {"V", "CV", true},
+		"CV can be followed by CVC":       {"CV", "CVC", true},
+		"VC can be followed by V":         {"VC", "V", true},
+		"VC cannot be followed by CV":     {"VC", "CV", false},
+		"CVC cannot be followed by CVC":   {"CVC", "CVC", false},
+		"unknown keys cannot be followed": {"CCV", "V", false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, syllable.AslanRules().CanBeFollowedBy(tc.key, tc.nextKey))
+		})
+	}
+}
+
+func TestShapes_should_be_the_four_kinds_of_syllable(t *testing.T) {
+	shapes := syllable.AslanRules().Shapes()
+
+	require.Len(t, shapes, 4)
+	assert.Equal(t, syllable.Shape{Key: "V", Weight: 3, Slots: []syllable.SlotKind{syllable.VowelSlot}}, shapes[0])
+	assert.Equal(t, syllable.Shape{Key: "CVC", Weight: 2, Slots: []syllable.SlotKind{syllable.FirstConsonantSlot, syllable.VowelSlot, syllable.LastConsonantSlot}}, shapes[3])
+}
+
+func TestVowelSwaps_next_vowels_should_never_repeat_a_single_vowel(t *testing.T) {
+	swaps := syllable.AslanRules().VowelSwaps()
+
+	require.Len(t, swaps, 10)
+	for _, swap := range swaps {
+		for _, single := range syllable.AslanRules().SingleVowels() {
+			_, inVowels := swap.Vowels[single]
+			_, inNextVowels := swap.NextVowels[single]
+			assert.False(t, inVowels && inNextVowels, "single vowel %s is in both tables", single)
+		}
+	}
+}
+
+func TestNextVowels(t *testing.T) {
+	testCases := map[string]struct {
+		key            string
+		vowel          string
+		nextKey        string
+		expectedVowel  string
+		expectedToHave bool
+	}{
+		"a single vowel cannot be repeated when both syllables meet with vowels": {"CV", "a", "V", "a", false},
+		"other vowels can follow a single vowel":                                 {"CV", "a", "VC", "e", true},
+		"a vowel of many letters can be repeated":                                {"V", "ea", "V", "ea", true},
+		"a single vowel can be repeated after a consonant":                       {"CVC", "a", "V", "a", true},
+		"a single vowel can be repeated before a consonant":                      {"CV", "a", "CV", "a", true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, ok := syllable.AslanRules().NextVowels(tc.key, tc.vowel, tc.nextKey)[tc.expectedVowel]
+			assert.Equal(t, tc.expectedToHave, ok)
+		})
+	}
+}
+
+func TestCollapses(t *testing.T) {
+	testCases := map[string]struct {
+		text     string
+		nextText string
+		expected bool
+	}{
+		"different letters are not collapsed":       {"kha", "ea", false},
+		"a repeated vowel is collapsed":             {"hka", "a", true},
+		"two consonants are kept":                   {"ahl", "lr", false},
+		"a third consonant is collapsed":            {"ahll", "lr", true},
+		"letters of the text itself are not tested": {"lll", "ra", false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, syllable.AslanRules().Collapses(tc.text, tc.nextText))
+		})
+	}
+}
+
+func TestNewRules_should_reject_an_invalid_phonology(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.Vowels = nil
+
+	_, err := syllable.NewRules(phonology)
+
+	assert.ErrorContains(t, err, "invalid phonology")
+}
+
+func TestNewRules_when_there_are_no_single_vowels_it_should_have_no_vowel_swaps(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.Vowels = []syllable.Phoneme{{Text: "ai", Weight: 1}, {Text: "ou", Weight: 2}}
+
+	rules, err := syllable.NewRules(phonology)
+
+	require.NoError(t, err)
+	assert.Empty(t, rules.VowelSwaps())
+	assert.Equal(t, map[string]int{"ai": 1, "ou": 2}, rules.Vowels())
+}
+
+func TestNewRules_should_take_the_weight_of_each_shape_from_the_phonology(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.SyllableWeights = map[string]int{"V": 1, "cvc": 7}
+
+	rules, err := syllable.NewRules(phonology)
+
+	require.NoError(t, err)
+	shapes := rules.Shapes()
+	assert.Equal(t, 1, shapes[0].Weight)
+	assert.Equal(t, 0, shapes[1].Weight)
+	assert.Equal(t, 7, shapes[3].Weight)
+}
+
+func TestNewRules_when_repeated_single_vowels_are_not_avoided_it_should_have_no_single_vowels(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.AvoidRepeatedSingleVowels = false
+
+	rules, err := syllable.NewRules(phonology)
+
+	require.NoError(t, err)
+	assert.Empty(t, rules.SingleVowels())
+	assert.Empty(t, rules.VowelSwaps())
+}
+
+func TestConsonantsAt(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.Positions = syllable.Positions{
+		Initial: syllable.PositionalConsonants{FirstConsonants: []syllable.Phoneme{{Text: "kh", Weight: 2}}},
+		Medial:  syllable.PositionalConsonants{LastConsonants: []syllable.Phoneme{{Text: "'", Weight: 1}}},
+		Final: syllable.PositionalConsonants{
+			FirstConsonants: []syllable.Phoneme{{Text: "ht", Weight: 1}},
+			LastConsonants:  []syllable.Phoneme{{Text: "r", Weight: 3}},
+		},
+	}
+	rules, err := syllable.NewRules(phonology)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		kind     syllable.SlotKind
+		first    bool
+		last     bool
+		expected map[string]int
+	}{
+		"first consonant of the first syllable":        {syllable.FirstConsonantSlot, true, false, map[string]int{"kh": 2}},
+		"first consonant of a single syllable":         {syllable.FirstConsonantSlot, true, true, map[string]int{"kh": 2}},
+		"first consonant of the last syllable":         {syllable.FirstConsonantSlot, false, true, map[string]int{"ht": 1}},
+		"first consonant of a medial syllable":         {syllable.FirstConsonantSlot, false, false, rules.FirstConsonants()},
+		"last consonant of a single syllable":          {syllable.LastConsonantSlot, true, true, map[string]int{"r": 3}},
+		"last consonant of the first syllable":         {syllable.LastConsonantSlot, true, false, rules.LastConsonants()},
+		"last consonant of a medial syllable":          {syllable.LastConsonantSlot, false, false, map[string]int{"'": 1}},
+		"vowels are the same wherever the syllable is": {syllable.VowelSlot, true, true, rules.Vowels()},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, rules.ConsonantsAt(tc.kind, tc.first, tc.last))
+		})
+	}
+	assert.True(t, rules.HasPositions())
+}
+
+func TestHasPositions_when_the_phonology_has_no_positions_it_should_be_false(t *testing.T) {
+	assert.False(t, syllable.AslanRules().HasPositions())
+}
+
+func TestNewRules_when_there_is_a_single_vowel_and_repeated_single_vowels_are_not_avoided_it_should_have_no_vowel_swaps(t *testing.T) {
+	phonology := syllable.AslanPhonology()
+	phonology.Vowels = []syllable.Phoneme{{Text: "a", Weight: 1}}
+	phonology.AvoidRepeatedSingleVowels = false
+
+	rules, err := syllable.NewRules(phonology)
+
+	require.NoError(t, err)
+	assert.Empty(t, rules.VowelSwaps())
+}
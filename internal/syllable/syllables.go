@@ -2,77 +2,100 @@
 package syllable
 
 import (
+	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 type template string
 
-const (
-	firstConsonant template = `<(f)|(f)|(f)|(f)|(f)|(ft)|(ft)|(ft)|(h)|(h)|(h)|(h)|(h)|(h)|(h)|(hf)|(hf)|(hk)|(hk)|(hk)|(hk)|(hk)|(hl)|(hl)|(hl)|(hr)|(hr)|(hr)|(ht)|(ht)|(ht)|(ht)|(ht)|(hw)|(hw)|(k)|(k)|(k)|(k)|(k)|(k)|(k)|(kh)|(kh)|(kh)|(kh)|(kh)|(kh)|(kht)|(kht)|(kht)|(kht)|(kt)|(kt)|(kt)|(kt)|(l)|(l)|(r)|(r)|(r)|(r)|(s)|(s)|(s)|(s)|(st)|(st)|(st)|(t)|(t)|(t)|(t)|(t)|(t)|(t)|(t)|(tl)|(tl)|(tr)|(tr)|(w)|(w)|(w)|(w)|(w)|(w)|>`
-	vowel          template = "<(a)|(a)|(a)|(a)|(a)|(a)|(a)|(a)|(a)|(a)|(ai)|(ai)|(ai)|(ao)|(ao)|(au)|(e)|(e)|(e)|(e)|(e)|(e)|(ea)|(ea)|(ea)|(ea)|(ea)|(ea)|(ei)|(ei)|(i)|(i)|(i)|(i)|(iy)|(iy)|(iy)|(o)|(o)|(oa)|(oi)|(oi)|(ou)|(u)|(ua)|(ui)|(ya)|(yu)|>"
-	lastConstant   template = "<(h)|(h)|(h)|(h)|(h)|(h)|(h)|(h)|(h)|(h)|(kh)|(kh)|(kh)|(kh)|(l)|(l)|(l)|(lr)|(lr)|(lr)|(lr)|(r)|(r)|(r)|(r)|(r)|(rl)|(rl)|(rl)|(rl)|(s)|(s)|(s)|(s)|(s)|(w)|(w)|(w)|(w)|(w)|(w)|(')|(')|(')>"
-
-	singleA template = "(a)|"
-	singleE template = "(e)|"
-	singleI template = "(i)|"
-	singleO template = "(o)|"
-	singleU template = "(u)|"
-)
-
-var (
-	vowelWithoutSingleA  = removeFromTemplate(vowel, singleA)
-	vowelWithOnlySingleA = removeFromTemplate(vowel, singleE, singleI, singleO, singleU)
-
-	vowelWithoutSingleE  = removeFromTemplate(vowel, singleE)
-	vowelWithOnlySingleE = removeFromTemplate(vowel, singleA, singleI, singleO, singleU)
-
-	vowelWithoutSingleI  = removeFromTemplate(vowel, singleI)
-	vowelWithOnlySingleI = removeFromTemplate(vowel, singleA, singleE, singleO, singleU)
-
-	vowelWithoutSingleO  = removeFromTemplate(vowel, singleO)
-	vowelWithOnlySingleO = removeFromTemplate(vowel, singleA, singleE, singleI, singleU)
-
-	vowelWithoutSingleU  = removeFromTemplate(vowel, singleU)
-	vowelWithOnlySingleU = removeFromTemplate(vowel, singleA, singleE, singleI, singleO)
-)
+// newTemplate builds a fantasyname template picking one of the phonemes, each one repeated as many times as its weight
+func newTemplate(phonemes []Phoneme) template {
+	options := make([]string, 0, len(phonemes))
+	for _, phoneme := range phonemes {
+		for range phoneme.Weight {
+			options = append(options, fmt.Sprintf("(%s)", phoneme.Text))
+		}
+	}
+	return template("<" + strings.Join(options, "|") + ">")
+}
 
 type swapKey string
 
-const (
-	withoutSingleA  swapKey = "withoutSingleA"
-	withoutSingleE  swapKey = "withoutSingleE"
-	withoutSingleI  swapKey = "withoutSingleI"
-	withoutSingleO  swapKey = "withoutSingleO"
-	withoutSingleU  swapKey = "withoutSingleU"
-	withOnlySingleA swapKey = "withOnlySingleA"
-	withOnlySingleE swapKey = "withOnlySingleE"
-	withOnlySingleI swapKey = "withOnlySingleI"
-	withOnlySingleO swapKey = "withOnlySingleO"
-	withOnlySingleU swapKey = "withOnlySingleU"
-)
+func withoutSingle(vowel string) swapKey {
+	return swapKey("withoutSingle" + strings.ToUpper(vowel))
+}
+
+func withOnlySingle(vowel string) swapKey {
+	return swapKey("withOnlySingle" + strings.ToUpper(vowel))
+}
 
 type templateSwap struct {
 	modifiedTemplate template
 	reverseSwapKey   swapKey
 }
 
-// swaps are used to enforce no consecutive single vowels are generated. They are meant to work as puzzle pieces
-var swaps = map[swapKey]templateSwap{
-	withoutSingleA:  {vowelWithoutSingleA, withOnlySingleA},
-	withoutSingleE:  {vowelWithoutSingleE, withOnlySingleE},
-	withoutSingleI:  {vowelWithoutSingleI, withOnlySingleI},
-	withoutSingleO:  {vowelWithoutSingleO, withOnlySingleO},
-	withoutSingleU:  {vowelWithoutSingleU, withOnlySingleU},
-	withOnlySingleA: {vowelWithOnlySingleA, withoutSingleA},
-	withOnlySingleE: {vowelWithOnlySingleE, withoutSingleE},
-	withOnlySingleI: {vowelWithOnlySingleI, withoutSingleI},
-	withOnlySingleO: {vowelWithOnlySingleO, withoutSingleO},
-	withOnlySingleU: {vowelWithOnlySingleU, withoutSingleU},
+// newSwaps builds the swaps used to enforce no consecutive single vowels are generated. They are meant to work as puzzle pieces:
+// for every single vowel there is a template without it whose reverse is a template where it is the only single vowel, and vice versa.
+func newSwaps(vowels []Phoneme) (map[swapKey]templateSwap, []swapKey) {
+	singleVowels := singleVowelsOf(vowels)
+	swaps := make(map[swapKey]templateSwap, 2*len(singleVowels))
+	allSwaps := make([]swapKey, 0, 2*len(singleVowels))
+	for _, single := range singleVowels {
+		swaps[withoutSingle(single)] = templateSwap{
+			modifiedTemplate: newTemplate(removePhonemes(vowels, single)),
+			reverseSwapKey:   withOnlySingle(single),
+		}
+		allSwaps = append(allSwaps, withoutSingle(single))
+	}
+	for _, single := range singleVowels {
+		swaps[withOnlySingle(single)] = templateSwap{
+			modifiedTemplate: newTemplate(removePhonemes(vowels, otherThan(singleVowels, single)...)),
+			reverseSwapKey:   withoutSingle(single),
+		}
+		allSwaps = append(allSwaps, withOnlySingle(single))
+	}
+	return swaps, allSwaps
+}
+
+// singleVowelsOf returns the vowels made of a single letter in the same order they have in the phonology
+func singleVowelsOf(vowels []Phoneme) []string {
+	var singles []string
+	for _, v := range vowels {
+		if utf8.RuneCountInString(v.Text) == 1 {
+			singles = append(singles, v.Text)
+		}
+	}
+	return singles
+}
+
+func removePhonemes(phonemes []Phoneme, textsToRemove ...string) []Phoneme {
+	var kept []Phoneme
+	for _, phoneme := range phonemes {
+		if !contains(textsToRemove, phoneme.Text) {
+			kept = append(kept, phoneme)
+		}
+	}
+	return kept
+}
+
+func otherThan(texts []string, excluded string) []string {
+	var others []string
+	for _, text := range texts {
+		if text != excluded {
+			others = append(others, text)
+		}
+	}
+	return others
 }
 
-var allSwaps = []swapKey{
-	withoutSingleA, withoutSingleE, withoutSingleI, withoutSingleO, withoutSingleU,
-	withOnlySingleA, withOnlySingleE, withOnlySingleI, withOnlySingleO, withOnlySingleU,
+func contains(texts []string, text string) bool {
+	for _, t := range texts {
+		if t == text {
+			return true
+		}
+	}
+	return false
 }
 
 type syllableKey string
@@ -92,52 +115,55 @@ const (
 	keyCVC = "cvc"
 )
 
+var allKeys = []syllableKey{keyV, keyCV, keyVC, keyCVC}
+
+// SlotKind is the kind of letters a slot of a syllable is made of
+type SlotKind int
+
+const (
+	// FirstConsonantSlot is the consonant that starts a syllable
+	FirstConsonantSlot SlotKind = iota
+	// VowelSlot is the vowel of a syllable
+	VowelSlot
+	// LastConsonantSlot is the consonant that ends a syllable
+	LastConsonantSlot
+)
+
+// Slot is each of the parts a syllable is made of along with the template to generate-word it
+type Slot struct {
+	Kind     SlotKind
+	Template string
+}
+
 type syllableDefinition interface {
 	Key() syllableKey
 	Weight() int
 	Template() template
+	Slots() []Slot
 	EnforceNoConsecutiveSingleVowels(nextSyllable syllableDefinition, generateRandomSwapVowelFn GenerateRandomIntegerUpToFn)
 	SwapVowelTemplate(swap templateSwap)
+	PlaceAt(index, numberOfSyllables int)
+	RestrictSlots(options [][]string)
 	SyllablesThatCanFollowThis() []syllableDefinition
 	StartsWithConsonant() bool
 }
 
 type syllable struct {
-	key                          syllableKey
-	weight                       int
-	vowelSwap                    *templateSwap
-	syllablesThatCanFollowThisFn func() []syllableDefinition
-}
-
-func newV() *syllable {
-	return &syllable{
-		key:                          keyV,
-		weight:                       3,
-		syllablesThatCanFollowThisFn: allSyllables,
-	}
+	key       syllableKey
+	weight    int
+	rules     *Rules
+	vowelSwap *templateSwap
+	first     bool
+	last      bool
+	// slotOptions are the options each slot is restricted to, see SyllableRestriction
+	slotOptions [][]string
 }
 
-func newCV() *syllable {
+func newSyllable(rules *Rules, key syllableKey) *syllable {
 	return &syllable{
-		key:                          keyCV,
-		weight:                       3,
-		syllablesThatCanFollowThisFn: allSyllables,
-	}
-}
-
-func newVC() *syllable {
-	return &syllable{
-		key:                          keyVC,
-		weight:                       2,
-		syllablesThatCanFollowThisFn: onlyVowelStartingSyllables,
-	}
-}
-
-func newCVC() *syllable {
-	return &syllable{
-		key:                          keyCVC,
-		weight:                       2,
-		syllablesThatCanFollowThisFn: onlyVowelStartingSyllables,
+		key:    key,
+		weight: rules.weights[key],
+		rules:  rules,
 	}
 }
 
@@ -151,70 +177,70 @@ func (d *syllable) Weight() int {
 
 func (d *syllable) vowelTemplate() template {
 	if d.vowelSwap == nil {
-		return vowel
+		return d.rules.vowel
 	}
 	return d.vowelSwap.modifiedTemplate
 }
 
 func (d *syllable) Template() template {
 	templateBuilder := new(strings.Builder)
+	for _, slot := range d.Slots() {
+		templateBuilder.WriteString(slot.Template)
+	}
+
+	return template(templateBuilder.String())
+}
+
+func (d *syllable) Slots() []Slot {
+	slots := make([]Slot, len(d.key))
 	for i, char := range d.key {
 		switch char {
 		case 'c':
 			if i == 0 {
-				templateBuilder.WriteString(string(firstConsonant))
+				slots[i] = Slot{Kind: FirstConsonantSlot, Template: string(d.rules.consonantTemplate(FirstConsonantSlot, d.first, d.last))}
 			} else {
-				templateBuilder.WriteString(string(lastConstant))
+				slots[i] = Slot{Kind: LastConsonantSlot, Template: string(d.rules.consonantTemplate(LastConsonantSlot, d.first, d.last))}
 			}
 		case 'v':
-			templateBuilder.WriteString(string(d.vowelTemplate()))
+			slots[i] = Slot{Kind: VowelSlot, Template: string(d.vowelTemplate())}
+		}
+		if i < len(d.slotOptions) {
+			slots[i].Template = string(template(slots[i].Template).restrictedTo(d.slotOptions[i]))
 		}
 	}
 
-	return template(templateBuilder.String())
+	return slots
 }
 
 func (d *syllable) EnforceNoConsecutiveSingleVowels(nextSyllable syllableDefinition, generateRandomSwapVowelFn GenerateRandomIntegerUpToFn) {
-	if d.key.EndsWithConsonant() || nextSyllable.StartsWithConsonant() {
+	if d.key.EndsWithConsonant() || nextSyllable.StartsWithConsonant() || len(d.rules.allSwaps) == 0 {
 		return
 	}
 	if d.vowelSwap == nil {
-		d.vowelSwap = pickRandomSwap(generateRandomSwapVowelFn)
+		d.vowelSwap = d.rules.pickRandomSwap(generateRandomSwapVowelFn)
 	}
-	nextSyllable.SwapVowelTemplate(swaps[d.vowelSwap.reverseSwapKey])
+	nextSyllable.SwapVowelTemplate(d.rules.swaps[d.vowelSwap.reverseSwapKey])
 }
 
 func (d *syllable) SwapVowelTemplate(swap templateSwap) {
 	d.vowelSwap = &swap
 }
 
-func (d *syllable) SyllablesThatCanFollowThis() []syllableDefinition {
-	return d.syllablesThatCanFollowThisFn()
+// PlaceAt sets where the syllable is in its word, which changes its consonants when the phonology has positions
+func (d *syllable) PlaceAt(index, numberOfSyllables int) {
+	d.first = index == 0
+	d.last = index == numberOfSyllables-1
 }
 
-func (d *syllable) StartsWithConsonant() bool {
-	return d.key.StartsWithConsonant()
+// RestrictSlots restricts each slot of the syllable to the given options, see SyllableRestriction
+func (d *syllable) RestrictSlots(options [][]string) {
+	d.slotOptions = options
 }
 
-func pickRandomSwap(randomIndexPicker GenerateRandomIntegerUpToFn) *templateSwap {
-	chosenSwapIndex := randomIndexPicker(len(swaps))
-	vowelTemplateSwapKey := allSwaps[chosenSwapIndex]
-	swap := swaps[vowelTemplateSwapKey]
-	return &swap
-}
-
-func removeFromTemplate(sourceTemplate template, templatesToRemove ...template) template {
-	newTemplate := string(sourceTemplate)
-	for _, templateToRemove := range templatesToRemove {
-		newTemplate = strings.ReplaceAll(newTemplate, string(templateToRemove), "")
-	}
-	return template(newTemplate)
-}
-
-func allSyllables() []syllableDefinition {
-	return []syllableDefinition{newV(), newCV(), newVC(), newCVC()}
+func (d *syllable) SyllablesThatCanFollowThis() []syllableDefinition {
+	return d.rules.syllables(d.rules.followers[d.key])
 }
 
-func onlyVowelStartingSyllables() []syllableDefinition {
-	return []syllableDefinition{newV(), newVC()}
+func (d *syllable) StartsWithConsonant() bool {
+	return d.key.StartsWithConsonant()
 }
@@ -0,0 +1,4 @@
+// Package aslanlexicon keeps a dictionary of generated words along with their meaning, like the glossary of a campaign.
+//
+// A Lexicon is saved to and loaded from a JSON file, and it can generate words that do not collide with its entries.
+package aslanlexicon
@@ -0,0 +1,61 @@
+package aslanlexicon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// lexiconFile is the content of the JSON file of a lexicon
+type lexiconFile struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads a lexicon saved with Lexicon.Save. The error wraps fs.ErrNotExist when the file does not exist.
+func Load(path string) (*Lexicon, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the lexicon: %w", err)
+	}
+	var file lexiconFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse the lexicon: %w", err)
+	}
+	lexicon := New()
+	for _, entry := range file.Entries {
+		if err := lexicon.Add(entry); err != nil {
+			return nil, fmt.Errorf("invalid lexicon: %w", err)
+		}
+	}
+	return lexicon, nil
+}
+
+// Save writes the lexicon to a JSON file, with its entries sorted by word so it can be kept under version control.
+// The file is replaced at once, so it is never left half written.
+func (l *Lexicon) Save(path string) error {
+	content, err := json.MarshalIndent(lexiconFile{Entries: l.Entries()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode the lexicon: %w", err)
+	}
+	temporary, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to write the lexicon: %w", err)
+	}
+	defer func() { _ = os.Remove(temporary.Name()) }()
+	if _, err := temporary.Write(append(content, '\n')); err != nil {
+		_ = temporary.Close()
+		return fmt.Errorf("unable to write the lexicon: %w", err)
+	}
+	if err := temporary.Close(); err != nil {
+		return fmt.Errorf("unable to write the lexicon: %w", err)
+	}
+	// temporary files are only readable by their owner, unlike the files written with os.WriteFile
+	if err := os.Chmod(temporary.Name(), 0o644); err != nil {
+		return fmt.Errorf("unable to write the lexicon: %w", err)
+	}
+	if err := os.Rename(temporary.Name(), path); err != nil {
+		return fmt.Errorf("unable to write the lexicon: %w", err)
+	}
+	return nil
+}
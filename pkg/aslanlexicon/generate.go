@@ -0,0 +1,49 @@
+package aslanlexicon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+// Avoid returns an option to discard the generated words that are already in the lexicon, see aslanwords.WithExclude.
+// Words are compared by their letters, so words stored with a style, like "Hko-a-seas", are discarded too.
+// Words added to the lexicon after creating a generator are discarded too.
+func (l *Lexicon) Avoid() aslanwords.GeneratorOption {
+	return aslanwords.WithExclude(l.containsSpelling)
+}
+
+// containsSpelling tells whether there is a word in the lexicon with the same letters as the given one
+func (l *Lexicon) containsSpelling(word string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.spellings[aslanwords.Spelling(word)] > 0
+}
+
+// Generate generates a word with the options that is not in the lexicon yet. It is not added to the lexicon.
+func (l *Lexicon) Generate(ctx context.Context, opts ...aslanwords.GeneratorOption) (string, error) {
+	return aslanwords.Generate(ctx, append(slices.Clone(opts), l.Avoid())...)
+}
+
+// maxSeeds is how many seeds GenerateFromSeed tries before giving up
+const maxSeeds = 1000
+
+// GenerateFromSeed generates a word with the options that is not in the lexicon yet, along with the seed it was generated with.
+// The word of the given seed is taken unless it is in the lexicon, then the following seeds are tried in turn.
+// Unlike Generate, generating a word with the options and the returned seed, see aslanwords.WithSeed, gives back the same word.
+// It is not added to the lexicon.
+func (l *Lexicon) GenerateFromSeed(ctx context.Context, seed uint64, opts ...aslanwords.GeneratorOption) (string, uint64, error) {
+	for range maxSeeds {
+		word, err := aslanwords.Generate(ctx, append(slices.Clone(opts), aslanwords.WithSeed(seed))...)
+		if err != nil {
+			return "", 0, err
+		}
+		if !l.containsSpelling(word) {
+			return word, seed, nil
+		}
+		seed++
+	}
+	return "", 0, fmt.Errorf("%w: the words of %d seeds in a row are in the lexicon", aslanwords.ErrExhausted, maxSeeds)
+}
@@ -0,0 +1,161 @@
+package aslanlexicon
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+// ErrDuplicateWord is returned when adding a word that is already in the lexicon
+var ErrDuplicateWord = errors.New("word already in the lexicon")
+
+// Entry is a word of the lexicon along with its meaning
+type Entry struct {
+	// Word is the word in lowercase
+	Word string `json:"word"`
+	// Gloss is the meaning of the word in English
+	Gloss string `json:"gloss"`
+	// PartOfSpeech is the kind of word, like noun or verb
+	PartOfSpeech string `json:"partOfSpeech,omitempty"`
+	Notes        string `json:"notes,omitempty"`
+	// Provenance tells how the word was generated, if it was
+	Provenance *Provenance `json:"provenance,omitempty"`
+}
+
+// Provenance is how a word was generated
+type Provenance struct {
+	Seed *uint64 `json:"seed,omitempty"`
+	// Options describes the options the word was generated with, like "number-of-syllables=3"
+	Options []string `json:"options,omitempty"`
+}
+
+// String returns the word along with its part of speech and gloss, like "hkoaseas (noun): star"
+func (e Entry) String() string {
+	if e.PartOfSpeech == "" {
+		return fmt.Sprintf("%s: %s", e.Word, e.Gloss)
+	}
+	return fmt.Sprintf("%s (%s): %s", e.Word, e.PartOfSpeech, e.Gloss)
+}
+
+// Lexicon is a dictionary of words and their meanings. Words are unique and are looked up ignoring their case.
+// It is safe for concurrent use.
+type Lexicon struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+	// spellings counts the entries by the letters of their word, see aslanwords.Spelling
+	spellings map[string]int
+}
+
+// New creates an empty lexicon
+func New() *Lexicon {
+	return &Lexicon{entries: make(map[string]Entry), spellings: make(map[string]int)}
+}
+
+// Add adds the entry to the lexicon, with its word lowercased. The word cannot be already in the lexicon and both
+// the word and the gloss are required.
+func (l *Lexicon) Add(entry Entry) error {
+	entry.Word = normalize(entry.Word)
+	entry.Gloss = strings.TrimSpace(entry.Gloss)
+	if entry.Word == "" {
+		return errors.New("word cannot be empty")
+	}
+	if entry.Gloss == "" {
+		return fmt.Errorf("gloss of %s cannot be empty", entry.Word)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.entries[entry.Word]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateWord, entry.Word)
+	}
+	l.entries[entry.Word] = entry
+	l.spellings[aslanwords.Spelling(entry.Word)]++
+	return nil
+}
+
+// Remove removes the word from the lexicon, telling whether it was there
+func (l *Lexicon) Remove(word string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := normalize(word)
+	if _, ok := l.entries[key]; !ok {
+		return false
+	}
+	delete(l.entries, key)
+	if l.spellings[aslanwords.Spelling(key)]--; l.spellings[aslanwords.Spelling(key)] == 0 {
+		delete(l.spellings, aslanwords.Spelling(key))
+	}
+	return true
+}
+
+// Lookup returns the entry of the word
+func (l *Lexicon) Lookup(word string) (Entry, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	entry, ok := l.entries[normalize(word)]
+	return entry, ok
+}
+
+// Contains tells whether the word is in the lexicon
+func (l *Lexicon) Contains(word string) bool {
+	_, ok := l.Lookup(word)
+	return ok
+}
+
+// Translate returns the entries whose gloss is the given one, ignoring its case, sorted by word
+func (l *Lexicon) Translate(gloss string) []Entry {
+	gloss = normalize(gloss)
+	return l.filter(func(entry Entry) bool {
+		return strings.ToLower(entry.Gloss) == gloss
+	})
+}
+
+// Search returns the entries containing the query in their word, gloss, part of speech or notes, ignoring its case,
+// sorted by word. An empty query returns every entry.
+func (l *Lexicon) Search(query string) []Entry {
+	query = normalize(query)
+	return l.filter(func(entry Entry) bool {
+		for _, field := range []string{entry.Word, entry.Gloss, entry.PartOfSpeech, entry.Notes} {
+			if strings.Contains(strings.ToLower(field), query) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Entries returns every entry of the lexicon sorted by word
+func (l *Lexicon) Entries() []Entry {
+	return l.filter(func(Entry) bool { return true })
+}
+
+// Len returns the number of entries of the lexicon
+func (l *Lexicon) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
+}
+
+func (l *Lexicon) filter(keep func(Entry) bool) []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var found []Entry
+	for _, entry := range l.entries {
+		if keep(entry) {
+			found = append(found, entry)
+		}
+	}
+	slices.SortFunc(found, func(a, b Entry) int {
+		return cmp.Compare(a.Word, b.Word)
+	})
+	return found
+}
+
+func normalize(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
@@ -0,0 +1,276 @@
+package aslanlexicon_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanlexicon"
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexicon_Add_it_should_store_the_word_in_lowercase(t *testing.T) {
+	lexicon := aslanlexicon.New()
+
+	require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: " Hkoaseas ", Gloss: "star", PartOfSpeech: "noun"}))
+
+	entry, found := lexicon.Lookup("HKOASEAS")
+	require.True(t, found)
+	assert.Equal(t, aslanlexicon.Entry{Word: "hkoaseas", Gloss: "star", PartOfSpeech: "noun"}, entry)
+	assert.Equal(t, "hkoaseas (noun): star", entry.String())
+	assert.Equal(t, 1, lexicon.Len())
+}
+
+func TestLexicon_Add_errors(t *testing.T) {
+	testCases := map[string]struct {
+		entry         aslanlexicon.Entry
+		expectedError string
+	}{
+		"empty word":     {aslanlexicon.Entry{Word: " ", Gloss: "star"}, "word cannot be empty"},
+		"empty gloss":    {aslanlexicon.Entry{Word: "hkoaseas"}, "gloss of hkoaseas cannot be empty"},
+		"duplicate word": {aslanlexicon.Entry{Word: "Ftahr", Gloss: "pride"}, "word already in the lexicon: ftahr"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			lexicon := aslanlexicon.New()
+			require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: "ftahr", Gloss: "honour"}))
+
+			err := lexicon.Add(tc.entry)
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLexicon_Add_when_the_word_is_already_there_it_should_return_ErrDuplicateWord(t *testing.T) {
+	lexicon := aslanlexicon.New()
+	require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: "ftahr", Gloss: "honour"}))
+
+	err := lexicon.Add(aslanlexicon.Entry{Word: "ftahr", Gloss: "pride"})
+
+	assert.ErrorIs(t, err, aslanlexicon.ErrDuplicateWord)
+}
+
+func TestLexicon_Remove_it_should_tell_whether_the_word_was_there(t *testing.T) {
+	lexicon := aslanlexicon.New()
+	require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: "ftahr", Gloss: "honour"}))
+
+	assert.True(t, lexicon.Remove("Ftahr"))
+	assert.False(t, lexicon.Contains("ftahr"))
+	assert.False(t, lexicon.Remove("ftahr"))
+}
+
+func TestLexicon_Translate_it_should_return_the_words_with_the_gloss(t *testing.T) {
+	lexicon := newTestLexicon(t)
+
+	words := lexicon.Translate("Star")
+
+	assert.Equal(t, []string{"hkoaseas", "yuteau"}, wordsOf(words))
+	assert.Empty(t, lexicon.Translate("moon"))
+}
+
+func TestLexicon_Search(t *testing.T) {
+	testCases := map[string]struct {
+		query         string
+		expectedWords []string
+	}{
+		"by word":           {"OASE", []string{"hkoaseas"}},
+		"by gloss":          {"hon", []string{"ftahr"}},
+		"by part of speech": {"noun", []string{"ftahr", "hkoaseas", "yuteau"}},
+		"by notes":          {"drinax", []string{"ftahr"}},
+		"empty query":       {"", []string{"eakhau", "ftahr", "hkoaseas", "yuteau"}},
+		"not found":         {"moon", nil},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			lexicon := newTestLexicon(t)
+
+			assert.Equal(t, tc.expectedWords, wordsOf(lexicon.Search(tc.query)))
+		})
+	}
+}
+
+func TestLexicon_Save_and_Load_it_should_keep_every_entry(t *testing.T) {
+	lexicon := newTestLexicon(t)
+	path := filepath.Join(t.TempDir(), "lexicon.json")
+
+	require.NoError(t, lexicon.Save(path))
+	loaded, err := aslanlexicon.Load(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, lexicon.Entries(), loaded.Entries())
+}
+
+func TestLoad_it_should_read_the_entries_of_the_file(t *testing.T) {
+	seed := uint64(0)
+	expectedEntries := []aslanlexicon.Entry{
+		{Word: "ftahr", Gloss: "honour", PartOfSpeech: "noun", Notes: "Taken from Pirates of Drinax"},
+		{Word: "hkoaseas", Gloss: "star", PartOfSpeech: "noun", Provenance: &aslanlexicon.Provenance{Seed: &seed, Options: []string{"number-of-syllables=3"}}},
+	}
+
+	lexicon, err := aslanlexicon.Load("testdata/lexicon.json")
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedEntries, lexicon.Entries())
+}
+
+func TestLoad_errors(t *testing.T) {
+	testCases := map[string]struct {
+		path          string
+		expectedError string
+	}{
+		"missing file":   {"testdata/missing.json", "unable to read the lexicon"},
+		"malformed file": {"testdata/malformed_lexicon.json", "unable to parse the lexicon"},
+		"duplicate word": {"testdata/duplicated_lexicon.json", "invalid lexicon: word already in the lexicon: ftahr"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanlexicon.Load(tc.path)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLoad_when_the_file_does_not_exist_it_should_return_ErrNotExist(t *testing.T) {
+	_, err := aslanlexicon.Load(filepath.Join(t.TempDir(), "lexicon.json"))
+
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLexicon_Save_when_the_directory_does_not_exist_it_should_return_error(t *testing.T) {
+	err := aslanlexicon.New().Save(filepath.Join(t.TempDir(), "missing", "lexicon.json"))
+
+	assert.ErrorContains(t, err, "unable to write the lexicon")
+}
+
+func TestLexicon_Save_it_should_not_leave_temporary_files(t *testing.T) {
+	directory := t.TempDir()
+
+	require.NoError(t, newTestLexicon(t).Save(filepath.Join(directory, "lexicon.json")))
+
+	files, err := os.ReadDir(directory)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestLexicon_Save_it_should_write_a_file_readable_by_everyone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lexicon.json")
+
+	require.NoError(t, newTestLexicon(t).Save(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o644), info.Mode().Perm())
+}
+
+func TestLexicon_Generate_it_should_not_generate_words_of_the_lexicon(t *testing.T) {
+	ctx := context.Background()
+	lexicon := aslanlexicon.New()
+	for seed := range uint64(30) {
+		word := aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed), aslanwords.WithNumberOfSyllables(1))
+		_ = lexicon.Add(aslanlexicon.Entry{Word: word, Gloss: "taken"})
+	}
+
+	for seed := range uint64(30) {
+		word, err := lexicon.Generate(ctx, aslanwords.WithSeed(seed), aslanwords.WithNumberOfSyllables(1))
+		require.NoError(t, err)
+		assert.False(t, lexicon.Contains(word), "word %s is already in the lexicon", word)
+	}
+}
+
+func TestLexicon_GenerateFromSeed_it_should_return_the_seed_that_generates_the_word(t *testing.T) {
+	ctx := context.Background()
+	lexicon := aslanlexicon.New()
+	taken := aslanwords.MustGenerate(ctx, aslanwords.WithSeed(7), aslanwords.WithNumberOfSyllables(2))
+	require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: taken, Gloss: "taken"}))
+
+	word, seed, err := lexicon.GenerateFromSeed(ctx, 7, aslanwords.WithNumberOfSyllables(2))
+
+	require.NoError(t, err)
+	assert.NotEqual(t, uint64(7), seed)
+	assert.False(t, lexicon.Contains(word), "word %s is already in the lexicon", word)
+	assert.Equal(t, word, aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed), aslanwords.WithNumberOfSyllables(2)))
+}
+
+func TestLexicon_GenerateFromSeed_when_the_word_of_the_seed_is_not_in_the_lexicon_it_should_keep_the_seed(t *testing.T) {
+	ctx := context.Background()
+
+	word, seed, err := aslanlexicon.New().GenerateFromSeed(ctx, 7, aslanwords.WithNumberOfSyllables(2))
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), seed)
+	assert.Equal(t, aslanwords.MustGenerate(ctx, aslanwords.WithSeed(7), aslanwords.WithNumberOfSyllables(2)), word)
+}
+
+func TestLexicon_Avoid_it_should_discard_the_words_added_after_creating_the_generator(t *testing.T) {
+	ctx := context.Background()
+	lexicon := aslanlexicon.New()
+	gen, err := aslanwords.New(aslanwords.WithNumberOfSyllables(1), lexicon.Avoid())
+	require.NoError(t, err)
+
+	for range 50 {
+		word, err := gen.Generate(ctx)
+		require.NoError(t, err)
+		require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: word, Gloss: "taken"}))
+	}
+}
+
+func TestLexicon_Generate_should_not_write_into_the_options_of_the_caller(t *testing.T) {
+	opts := make([]aslanwords.GeneratorOption, 1, 2)
+	opts[0] = aslanwords.WithNumberOfSyllables(1)
+
+	_, err := aslanlexicon.New().Generate(context.Background(), opts...)
+	require.NoError(t, err)
+
+	assert.Nil(t, opts[:2][1])
+}
+
+func TestLexicon_Avoid_when_the_words_are_stored_with_a_style_it_should_discard_them(t *testing.T) {
+	ctx := context.Background()
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}, {Text: "e", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+	lexicon := aslanlexicon.New()
+	gen, err := aslanwords.New(aslanwords.WithSeed(1), aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllables(2),
+		aslanwords.WithSyllableSeparator("-"), aslanwords.WithCase(aslanwords.TitleCase), lexicon.Avoid())
+	require.NoError(t, err)
+
+	// the phonology only has four words of two syllables: kaka, kake, keka and keke
+	for range 4 {
+		word, err := gen.Generate(ctx)
+		require.NoError(t, err)
+		require.NoError(t, lexicon.Add(aslanlexicon.Entry{Word: word, Gloss: "taken"}))
+	}
+	_, err = gen.Generate(ctx)
+	assert.Error(t, err)
+}
+
+func newTestLexicon(t *testing.T) *aslanlexicon.Lexicon {
+	t.Helper()
+	lexicon := aslanlexicon.New()
+	for _, entry := range []aslanlexicon.Entry{
+		{Word: "hkoaseas", Gloss: "star", PartOfSpeech: "noun"},
+		{Word: "yuteau", Gloss: "star", PartOfSpeech: "noun", Provenance: &aslanlexicon.Provenance{Options: []string{"profile=aslan"}}},
+		{Word: "ftahr", Gloss: "honour", PartOfSpeech: "noun", Notes: "Taken from Pirates of Drinax"},
+		{Word: "eakhau", Gloss: "to hunt", PartOfSpeech: "verb"},
+	} {
+		require.NoError(t, lexicon.Add(entry))
+	}
+	return lexicon
+}
+
+func wordsOf(entries []aslanlexicon.Entry) []string {
+	var words []string
+	for _, entry := range entries {
+		words = append(words, entry.Word)
+	}
+	return words
+}
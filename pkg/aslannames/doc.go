@@ -0,0 +1,5 @@
+// Package aslannames generates full Aslan names made of several words, like a given name and a clan name.
+//
+// The words of a name are its parts. Each part is generated with its own options of the aslanwords package, so parts can
+// have different lengths, profiles or styles, and a Structure template tells how the parts are written together.
+package aslannames
@@ -0,0 +1,69 @@
+package aslannames
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+// Generator generates names with the same structure. It is safe for concurrent use.
+type Generator struct {
+	structure Structure
+	parts     []*aslanwords.Generator
+}
+
+// New creates a Generator with the given options. By default, it generates names with the DefaultStructure.
+func New(opts ...Option) (*Generator, error) {
+	options := &generateOptions{structure: DefaultStructure()}
+	for _, o := range opts {
+		o(options)
+	}
+	if err := options.structure.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid structure: %w", err)
+	}
+
+	seed := rand.Uint64()
+	if options.seed != nil {
+		seed = *options.seed
+	}
+	g := &Generator{structure: options.structure}
+	for i, part := range options.structure.Parts {
+		// every part gets its own source, overriding any source of the shared word options
+		partOptions := slices.Concat(options.wordOptions,
+			[]aslanwords.GeneratorOption{aslanwords.WithRandSource(rand.NewPCG(seed, uint64(i)))}, part.Options)
+		gen, err := aslanwords.New(partOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("part %s: %w", part.Name, err)
+		}
+		g.parts = append(g.parts, gen)
+	}
+	return g, nil
+}
+
+// Generate generates a random name
+func (g *Generator) Generate(ctx context.Context) (Name, error) {
+	name := Name{parts: make([]NamePart, len(g.parts))}
+	texts := make([]string, len(g.parts))
+	for i, gen := range g.parts {
+		word, err := gen.GenerateWord(ctx)
+		if err != nil {
+			return Name{}, fmt.Errorf("part %s: %w", g.structure.Parts[i].Name, err)
+		}
+		texts[i] = gen.Styled(word)
+		name.parts[i] = NamePart{Name: g.structure.Parts[i].Name, Word: word, Text: texts[i]}
+	}
+	name.text = g.structure.write(texts)
+	return name, nil
+}
+
+// Generate generates a random name with the given options. By default, it generates a given name followed by a clan name.
+func Generate(ctx context.Context, opts ...Option) (Name, error) {
+	gen, err := New(opts...)
+	if err != nil {
+		return Name{}, err
+	}
+	return gen.Generate(ctx)
+}
@@ -0,0 +1,55 @@
+package aslannames
+
+import (
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+// Name is a generated name made of several words
+type Name struct {
+	text  string
+	parts []NamePart
+}
+
+// NamePart is one of the words of a name
+type NamePart struct {
+	// Name identifies the part in the structure of the name
+	Name string
+	// Word is the generated word along with its syllables
+	Word aslanwords.Word
+	// Text is the word as written in the name, with the style of the part
+	Text string
+}
+
+// String returns the whole name as written with the template of its structure
+func (n Name) String() string {
+	return n.text
+}
+
+// Parts returns the words of the name in the order of the parts of its structure
+func (n Name) Parts() []NamePart {
+	return slices.Clone(n.parts)
+}
+
+// Part returns the part of the name with the given name
+func (n Name) Part(name string) (NamePart, bool) {
+	for _, part := range n.parts {
+		if part.Name == name {
+			return part, true
+		}
+	}
+	return NamePart{}, false
+}
+
+// Given returns the given name, as written in the name, of names with the default structure
+func (n Name) Given() string {
+	part, _ := n.Part(GivenPart)
+	return part.Text
+}
+
+// Clan returns the clan name, as written in the name, of names with the default structure
+func (n Name) Clan() string {
+	part, _ := n.Part(ClanPart)
+	return part.Text
+}
@@ -0,0 +1,153 @@
+package aslannames_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslannames"
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_by_default_it_should_generate_a_given_name_followed_by_a_clan_name(t *testing.T) {
+	for seed := range uint64(20) {
+		name, err := aslannames.Generate(context.Background(), aslannames.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.Equal(t, name.Given()+" "+name.Clan(), name.String())
+		assert.Regexp(t, "^[A-Z][^A-Z ]*$", name.Given())
+		assert.Regexp(t, "^[A-Z][^A-Z ]*$", name.Clan())
+		given, _ := name.Part(aslannames.GivenPart)
+		assert.GreaterOrEqual(t, len(given.Word.Syllables()), 1)
+		assert.LessOrEqual(t, len(given.Word.Syllables()), 2)
+		clan, _ := name.Part(aslannames.ClanPart)
+		assert.GreaterOrEqual(t, len(clan.Word.Syllables()), 3)
+		assert.LessOrEqual(t, len(clan.Word.Syllables()), 4)
+	}
+}
+
+func TestGenerate_when_called_with_the_same_seed_it_should_always_generate_the_same_name(t *testing.T) {
+	ctx := context.Background()
+	expectedName, err := aslannames.Generate(ctx, aslannames.WithSeed(42))
+	require.NoError(t, err)
+
+	for range 10 {
+		name, err := aslannames.Generate(ctx, aslannames.WithSeed(42))
+		require.NoError(t, err)
+		assert.Equal(t, expectedName, name)
+	}
+}
+
+func TestGenerate_with_structure_it_should_write_the_parts_with_its_template(t *testing.T) {
+	structure := aslannames.Structure{
+		Template: "{title} {given} of clan {clan}",
+		Parts: []aslannames.Part{
+			{Name: "given", Options: []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(2), aslanwords.WithCase(aslanwords.TitleCase)}},
+			{Name: "clan", Options: []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(4), aslanwords.WithCase(aslanwords.UpperCase)}},
+			{Name: "title", Options: []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(1)}},
+		},
+	}
+
+	name, err := aslannames.Generate(context.Background(), aslannames.WithStructure(structure))
+	require.NoError(t, err)
+
+	parts := name.Parts()
+	require.Len(t, parts, 3)
+	assert.Equal(t, parts[2].Text+" "+parts[0].Text+" of clan "+parts[1].Text, name.String())
+	assertSyllables(t, 2, parts[0].Word)
+	assert.Regexp(t, "^[A-Z][^A-Z]*$", parts[0].Text)
+	assertSyllables(t, 4, parts[1].Word)
+	assert.Regexp(t, "^[^a-z]*$", parts[1].Text)
+	assert.Equal(t, parts[2].Word.String(), parts[2].Text)
+}
+
+func TestGenerate_when_the_template_is_empty_it_should_write_the_parts_separated_by_spaces(t *testing.T) {
+	structure := aslannames.Structure{Parts: []aslannames.Part{{Name: "first"}, {Name: "second"}}}
+
+	name, err := aslannames.Generate(context.Background(), aslannames.WithStructure(structure))
+	require.NoError(t, err)
+
+	first, _ := name.Part("first")
+	second, _ := name.Part("second")
+	assert.Equal(t, first.Text+" "+second.Text, name.String())
+}
+
+func TestGenerate_with_word_options_they_should_apply_to_every_part_before_its_own_options(t *testing.T) {
+	structure := aslannames.Structure{Parts: []aslannames.Part{
+		{Name: "short"},
+		{Name: "long", Options: []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(3)}},
+	}}
+
+	name, err := aslannames.Generate(context.Background(), aslannames.WithStructure(structure),
+		aslannames.WithWordOptions(aslanwords.WithNumberOfSyllables(1)))
+	require.NoError(t, err)
+
+	short, _ := name.Part("short")
+	assertSyllables(t, 1, short.Word)
+	long, _ := name.Part("long")
+	assertSyllables(t, 3, long.Word)
+}
+
+// assertSyllables asserts the word was generated with the given number of syllables. Syllables whose letters are all
+// collapsed are merged, so the word may have fewer of them but it can still be split into that many.
+func assertSyllables(t *testing.T, numberOfSyllables int, word aslanwords.Word) {
+	t.Helper()
+	assert.LessOrEqual(t, len(word.Syllables()), numberOfSyllables)
+	probability, err := aslanwords.Probability(word.String(), aslanwords.WithNumberOfSyllables(numberOfSyllables))
+	require.NoError(t, err)
+	assert.Positive(t, probability, "%s cannot be split into %d syllables", word, numberOfSyllables)
+}
+
+func TestGenerate_when_word_options_have_a_random_source_each_part_should_get_its_own(t *testing.T) {
+	structure := aslannames.Structure{Parts: []aslannames.Part{{Name: "first"}, {Name: "second"}}}
+	testCases := map[string]aslanwords.GeneratorOption{
+		"seed":   aslanwords.WithSeed(7),
+		"source": aslanwords.WithRandSource(rand.NewPCG(7, 7)),
+	}
+	for name, source := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gen, err := aslannames.New(aslannames.WithStructure(structure), aslannames.WithWordOptions(source))
+			require.NoError(t, err)
+
+			var differentParts bool
+			for range 20 {
+				name, err := gen.Generate(context.Background())
+				require.NoError(t, err)
+				first, _ := name.Part("first")
+				second, _ := name.Part("second")
+				differentParts = differentParts || first.Text != second.Text
+			}
+			assert.True(t, differentParts, "both parts should not be generated with the same source")
+		})
+	}
+}
+
+func TestName_Part_when_the_part_does_not_exist_it_should_not_be_found(t *testing.T) {
+	name, err := aslannames.Generate(context.Background())
+	require.NoError(t, err)
+
+	_, found := name.Part("nickname")
+	assert.False(t, found)
+}
+
+func TestNew_errors(t *testing.T) {
+	testCases := map[string]struct {
+		structure     aslannames.Structure
+		expectedError string
+	}{
+		"no parts":       {aslannames.Structure{}, "invalid structure: a name needs at least one part"},
+		"unnamed part":   {aslannames.Structure{Parts: []aslannames.Part{{Name: " "}}}, "invalid structure: part 1 must have a name"},
+		"repeated part":  {aslannames.Structure{Parts: []aslannames.Part{{Name: "a"}, {Name: "a"}}}, "invalid structure: part a is repeated"},
+		"unknown part":   {aslannames.Structure{Template: "{a} {b}", Parts: []aslannames.Part{{Name: "a"}}}, `invalid structure: template refers to unknown part "b"`},
+		"invalid option": {aslannames.Structure{Parts: []aslannames.Part{{Name: "a", Options: []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(0)}}}}, "part a: invalid options"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslannames.New(aslannames.WithStructure(tc.structure))
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package aslannames
+
+import (
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+// Option configures the generation of names
+type Option func(*generateOptions)
+
+type generateOptions struct {
+	structure   Structure
+	wordOptions []aslanwords.GeneratorOption
+	seed        *uint64
+}
+
+// WithStructure generates names with the given structure instead of the DefaultStructure
+func WithStructure(structure Structure) Option {
+	return func(o *generateOptions) {
+		o.structure = structure
+	}
+}
+
+// WithWordOptions sets options shared by every part of the name, like aslanwords.WithProfile or aslanwords.WithAvoidCanon.
+// The options of each part are applied after them. Random sources set here, like aslanwords.WithSeed, are ignored
+// because each part needs its own: use WithSeed instead.
+func WithWordOptions(opts ...aslanwords.GeneratorOption) Option {
+	return func(o *generateOptions) {
+		o.wordOptions = append(o.wordOptions, opts...)
+	}
+}
+
+// WithSeed makes the generation reproducible: the same seed with the same options will always generate the same name
+func WithSeed(seed uint64) Option {
+	return func(o *generateOptions) {
+		o.seed = &seed
+	}
+}
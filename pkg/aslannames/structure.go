@@ -0,0 +1,88 @@
+package aslannames
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+const (
+	// GivenPart is the name of the part of the default structure with the given name
+	GivenPart = "given"
+	// ClanPart is the name of the part of the default structure with the clan name
+	ClanPart = "clan"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Structure is how a name is built: the words it is made of and how they are written together
+type Structure struct {
+	// Template is how the name is written, with the name of each part between braces, like "{given} of clan {clan}".
+	// When empty, the parts are written in order separated by spaces.
+	Template string
+	// Parts are the words of the name
+	Parts []Part
+}
+
+// Part is a word of a name
+type Part struct {
+	// Name identifies the part in the template
+	Name string
+	// Options are the options the word is generated with, like its number of syllables or its style
+	Options []aslanwords.GeneratorOption
+}
+
+// DefaultStructure returns the structure of a usual Aslan name: a short given name of one or two syllables followed by
+// a longer clan name of three or four syllables, both in title case
+func DefaultStructure() Structure {
+	return Structure{
+		Template: "{" + GivenPart + "} {" + ClanPart + "}",
+		Parts: []Part{
+			{Name: GivenPart, Options: []aslanwords.GeneratorOption{
+				aslanwords.WithNumberOfSyllablesBetween(1, 3), aslanwords.WithCase(aslanwords.TitleCase),
+			}},
+			{Name: ClanPart, Options: []aslanwords.GeneratorOption{
+				aslanwords.WithNumberOfSyllablesBetween(3, 5), aslanwords.WithCase(aslanwords.TitleCase),
+			}},
+		},
+	}
+}
+
+// Validate checks the parts have different names and the template only refers to them
+func (s Structure) Validate() error {
+	if len(s.Parts) == 0 {
+		return fmt.Errorf("a name needs at least one part")
+	}
+	names := make(map[string]bool, len(s.Parts))
+	for i, part := range s.Parts {
+		if strings.TrimSpace(part.Name) == "" {
+			return fmt.Errorf("part %d must have a name", i+1)
+		}
+		if names[part.Name] {
+			return fmt.Errorf("part %s is repeated", part.Name)
+		}
+		names[part.Name] = true
+	}
+	for _, placeholder := range placeholderRegexp.FindAllStringSubmatch(s.Template, -1) {
+		if !names[placeholder[1]] {
+			return fmt.Errorf("template refers to unknown part %q", placeholder[1])
+		}
+	}
+	return nil
+}
+
+// write returns the name written with the template, given the text of each part in the same order as the parts
+func (s Structure) write(texts []string) string {
+	if s.Template == "" {
+		return strings.Join(texts, " ")
+	}
+	textByPart := make(map[string]string, len(s.Parts))
+	for i, part := range s.Parts {
+		textByPart[part.Name] = texts[i]
+	}
+	return placeholderRegexp.ReplaceAllStringFunc(s.Template, func(placeholder string) string {
+		return textByPart[placeholder[1:len(placeholder)-1]]
+	})
+}
@@ -0,0 +1,15 @@
+// Package aslansyllable exposes the templates Aslan words are generated from, so custom samplers and exporters can be built
+// on top of the official rules instead of copying the tables.
+//
+// A Template is the sequence of syllables of a word where every syllable is made of slots: first consonant, vowel and last
+// consonant. Each slot holds a template with the syntax of https://github.com/s0rg/fantasyname?tab=readme-ov-file#pattern-syntax
+// that Template.Expand turns into text.
+//
+// The API of this package is stable and follows the semantic versioning of the module: it will not change in a
+// backwards-incompatible way until the next major version. The same goes for the words: expanding a template generated
+// with the same random function always produces the same word in every release of the same major version, as the words
+// of aslanwords.ForKey do, so the rules are only tuned in a new major version.
+//
+// The promise about the words starts with the first release that ships this package, the one following 1.0.0.
+// The rules were tuned before it, so the words of a seed in 1.0.0 and earlier releases may differ from the ones of later releases.
+package aslansyllable
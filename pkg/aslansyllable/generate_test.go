@@ -0,0 +1,52 @@
+package aslansyllable_test
+
+import (
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslansyllable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files. The words expanded from the same random numbers are part of the public contract,
+// so the golden file of Generate can only change in a new major version.
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+const generateGoldenFile = "testdata/generate.golden"
+
+var generateGoldenCases = []struct {
+	name              string
+	numberOfSyllables int
+	opts              []aslansyllable.Option
+}{
+	{"one syllable", 1, nil},
+	{"two syllables", 2, nil},
+	{"three syllables", 3, nil},
+	{"five syllables", 5, nil},
+	{"first and last syllable", 3, []aslansyllable.Option{aslansyllable.WithFirstSyllableIn("CV"), aslansyllable.WithLastSyllableIn("VC")}},
+}
+
+func TestGenerate_should_expand_into_the_words_of_the_golden_file(t *testing.T) {
+	var golden strings.Builder
+	for _, tc := range generateGoldenCases {
+		for seed := range uint64(10) {
+			random := rand.New(rand.NewPCG(seed, seed))
+			template := aslansyllable.Generate(tc.numberOfSyllables, append(tc.opts, aslansyllable.WithRandomIntegerUpTo(random.IntN))...)
+			word, err := template.Expand(random.IntN)
+			require.NoError(t, err)
+			_, _ = fmt.Fprintf(&golden, "%s\t%d\t%s\t%s\n", tc.name, seed, strings.Join(template.Keys(), " "), word)
+		}
+	}
+
+	if *update {
+		require.NoError(t, os.WriteFile(generateGoldenFile, []byte(golden.String()), 0o644))
+	}
+	expected, err := os.ReadFile(generateGoldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), golden.String(), "the words of the random numbers have changed, which breaks the compatibility of the major version")
+}
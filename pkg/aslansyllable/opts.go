@@ -0,0 +1,47 @@
+package aslansyllable
+
+import (
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// Option configures the generation of templates
+type Option func(*generateOptions)
+
+type generateOptions struct {
+	templateOptions []syllable.TemplateOption
+}
+
+// WithRandomIntegerUpTo sets the random function used to pick the syllables and the vowel tables of the template.
+// By default, or when it is nil, the global generator of math/rand/v2 is used.
+func WithRandomIntegerUpTo(randomIntegerUpTo RandomIntegerUpToFn) Option {
+	return func(o *generateOptions) {
+		if randomIntegerUpTo == nil {
+			return
+		}
+		o.templateOptions = append(o.templateOptions,
+			syllable.WithSyllableChanceGenerator(randomIntegerUpTo),
+			syllable.WithVowelTemplateChanceGenerator(randomIntegerUpTo),
+		)
+	}
+}
+
+// WithRules builds the syllables with the given rules instead of the Aslan ones
+func WithRules(rules *Rules) Option {
+	return func(o *generateOptions) {
+		o.templateOptions = append(o.templateOptions, syllable.WithRules(rules))
+	}
+}
+
+// WithFirstSyllableIn restricts the first syllable to the ones with any of the given keys
+func WithFirstSyllableIn(keys ...string) Option {
+	return func(o *generateOptions) {
+		o.templateOptions = append(o.templateOptions, syllable.WithFirstSyllableIn(keys...))
+	}
+}
+
+// WithLastSyllableIn restricts the last syllable to the ones with any of the given keys
+func WithLastSyllableIn(keys ...string) Option {
+	return func(o *generateOptions) {
+		o.templateOptions = append(o.templateOptions, syllable.WithLastSyllableIn(keys...))
+	}
+}
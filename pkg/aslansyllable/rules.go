@@ -0,0 +1,41 @@
+package aslansyllable
+
+import (
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// Phonology is the inventory of consonants and vowels, with their weights, along with the kinds of syllable words are made of
+type Phonology = syllable.Phonology
+
+// Phoneme is a consonant or vowel of a Phonology along with the weight used to pick it
+type Phoneme = syllable.Phoneme
+
+// Positions holds the consonants used instead of the ones of a Phonology by the syllables at the start, in the middle or at the end of the words
+type Positions = syllable.Positions
+
+// PositionalConsonants are the consonants the syllables at a position of the word start and end with
+type PositionalConsonants = syllable.PositionalConsonants
+
+// Rules are the templates and rules to build syllables compiled from a Phonology
+type Rules = syllable.Rules
+
+// Shape is a kind of syllable: its key, its weight and its slots
+type Shape = syllable.Shape
+
+// VowelSwap holds the vowels two consecutive syllables can have when the first one ends with vowel and the next one starts with vowel
+type VowelSwap = syllable.VowelSwap
+
+// AslanPhonology returns the phonology of the Aslan language. Every call returns a new copy that can be safely modified.
+func AslanPhonology() Phonology {
+	return syllable.AslanPhonology()
+}
+
+// AslanRules returns the rules of the Aslan language, the ones used by default
+func AslanRules() *Rules {
+	return syllable.AslanRules()
+}
+
+// NewRules compiles the rules of the given phonology, returning an error if the phonology is not valid
+func NewRules(phonology Phonology) (*Rules, error) {
+	return syllable.NewRules(phonology)
+}
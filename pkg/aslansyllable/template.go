@@ -0,0 +1,103 @@
+package aslansyllable
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+	"github.com/s0rg/fantasyname"
+	"github.com/s0rg/fantasyname/stringers"
+	"github.com/s0rg/fantasyname/wrappers"
+)
+
+// RandomIntegerUpToFn is a function that is expected to generate a positive integer from zero up to the given number minus one
+type RandomIntegerUpToFn = syllable.GenerateRandomIntegerUpToFn
+
+// SlotKind is the kind of letters a slot of a syllable is made of
+type SlotKind = syllable.SlotKind
+
+const (
+	// FirstConsonantSlot is the consonant that starts a syllable
+	FirstConsonantSlot = syllable.FirstConsonantSlot
+	// VowelSlot is the vowel of a syllable
+	VowelSlot = syllable.VowelSlot
+	// LastConsonantSlot is the consonant that ends a syllable
+	LastConsonantSlot = syllable.LastConsonantSlot
+)
+
+// Slot is each of the parts a syllable is made of along with the template to generate it
+type Slot = syllable.Slot
+
+// Syllable is a syllable of a template where Key is one of V, CV, VC or CVC
+type Syllable struct {
+	Key   string
+	Slots []Slot
+}
+
+// Template returns the template of the whole syllable
+func (s Syllable) Template() string {
+	var template strings.Builder
+	for _, slot := range s.Slots {
+		template.WriteString(slot.Template)
+	}
+	return template.String()
+}
+
+// Template is the sequence of syllables of a word
+type Template []Syllable
+
+// Generate generates the template of a word with the given number of syllables following the Aslan rules, or the ones
+// set with WithRules. It returns an empty template when the number of syllables is lower than one.
+func Generate(numberOfSyllables int, opts ...Option) Template {
+	options := &generateOptions{}
+	for _, o := range opts {
+		o(options)
+	}
+	definition := syllable.GenerateTemplate(numberOfSyllables, options.templateOptions...)
+
+	keys := definition.SyllableKeySequence()
+	template := make(Template, len(definition))
+	for i, slots := range definition.SlotSequence() {
+		template[i] = Syllable{Key: keys[i], Slots: slots}
+	}
+	return template
+}
+
+// Keys returns the sequence of keys of the syllables of the template
+func (t Template) Keys() []string {
+	keys := make([]string, len(t))
+	for i, s := range t {
+		keys[i] = s.Key
+	}
+	return keys
+}
+
+// String returns the template of the whole word
+func (t Template) String() string {
+	var template strings.Builder
+	for _, s := range t {
+		template.WriteString(s.Template())
+	}
+	return template.String()
+}
+
+// Expand generates the text of the template picking every option with the given random function, the global generator
+// of math/rand/v2 is used when it is nil. Letters repeated by the concatenation of syllables are collapsed the same way
+// aslanwords does, so expanding a template with the random function used to generate it produces the same word as aslanwords.
+func (t Template) Expand(randomIntegerUpTo RandomIntegerUpToFn) (string, error) {
+	if randomIntegerUpTo == nil {
+		randomIntegerUpTo = rand.IntN
+	}
+	var text strings.Builder
+	for _, s := range t {
+		for _, slot := range s.Slots {
+			gen, err := fantasyname.Compile(slot.Template, fantasyname.RandFn(randomIntegerUpTo))
+			if err != nil {
+				return "", fmt.Errorf("invalid template %q: %w", slot.Template, err)
+			}
+			text.WriteString(gen.String())
+		}
+	}
+	return wrappers.Collapsed(stringers.Literal(text.String())).String(), nil
+}
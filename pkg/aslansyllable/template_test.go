@@ -0,0 +1,84 @@
+package aslansyllable_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslansyllable"
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_should_generate_the_given_number_of_syllables(t *testing.T) {
+	template := aslansyllable.Generate(4)
+
+	require.Len(t, template, 4)
+	for _, s := range template {
+		assert.Contains(t, []string{"V", "CV", "VC", "CVC"}, s.Key)
+		assert.Len(t, s.Slots, len(s.Key))
+	}
+	assert.Equal(t, template.Keys(), []string{template[0].Key, template[1].Key, template[2].Key, template[3].Key})
+}
+
+func TestGenerate_when_number_of_syllables_is_lower_than_one_it_should_be_empty(t *testing.T) {
+	assert.Empty(t, aslansyllable.Generate(0))
+}
+
+func TestGenerate_should_follow_the_restrictions_of_first_and_last_syllable(t *testing.T) {
+	for range 50 {
+		keys := aslansyllable.Generate(3, aslansyllable.WithFirstSyllableIn("CV"), aslansyllable.WithLastSyllableIn("VC")).Keys()
+
+		assert.Equal(t, "CV", keys[0])
+		assert.Equal(t, "VC", keys[2])
+	}
+}
+
+func TestTemplate_String_should_join_the_templates_of_the_syllables(t *testing.T) {
+	template := aslansyllable.Generate(2)
+
+	assert.Equal(t, template[0].Template()+template[1].Template(), template.String())
+	assert.True(t, strings.HasPrefix(template.String(), "<"))
+}
+
+func TestTemplate_Expand_with_the_random_function_of_the_template_should_generate_the_same_word_as_aslanwords(t *testing.T) {
+	ctx := context.Background()
+
+	for seed := range uint64(50) {
+		random := rand.New(rand.NewPCG(seed, seed))
+		template := aslansyllable.Generate(3, aslansyllable.WithRandomIntegerUpTo(random.IntN))
+
+		word, err := template.Expand(random.IntN)
+
+		require.NoError(t, err)
+		assert.Equal(t, aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed), aslanwords.WithNumberOfSyllables(3)), word)
+	}
+}
+
+func TestTemplate_Expand_when_a_slot_template_is_invalid_it_should_return_error(t *testing.T) {
+	template := aslansyllable.Template{{Key: "V", Slots: []aslansyllable.Slot{{Kind: aslansyllable.VowelSlot, Template: "<(a)"}}}}
+
+	_, err := template.Expand(nil)
+
+	assert.ErrorContains(t, err, "invalid template")
+}
+
+func TestWithRules_should_build_the_syllables_with_the_given_rules(t *testing.T) {
+	phonology := aslansyllable.AslanPhonology()
+	phonology.Vowels = []aslansyllable.Phoneme{{Text: "o", Weight: 1}}
+	phonology.AvoidRepeatedSingleVowels = false
+	rules, err := aslansyllable.NewRules(phonology)
+	require.NoError(t, err)
+
+	template := aslansyllable.Generate(3, aslansyllable.WithRules(rules))
+
+	for _, s := range template {
+		for _, slot := range s.Slots {
+			if slot.Kind == aslansyllable.VowelSlot {
+				assert.Equal(t, "<(o)>", slot.Template)
+			}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Package aslantext generates paragraphs of Aslan-looking text, the Aslan lorem ipsum, for handouts, signs or the screens
+// of a ship computer.
+//
+// Sentences are made of the words of a vocabulary generated with the aslanwords package. Words are picked following
+// Zipf's law: the first words of the vocabulary, short function words, recur in almost every sentence while the last ones
+// are rare, the way words are used in natural languages.
+package aslantext
@@ -0,0 +1,119 @@
+package aslantext
+
+import (
+	"fmt"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+// Option configures the generation of text
+type Option func(*generateOptions)
+
+// span is a range of amounts, both included
+type span struct {
+	min int
+	max int
+}
+
+func (s span) validate(name string) error {
+	if s.min < 1 {
+		return fmt.Errorf("minimum number of %s must be one or greater", name)
+	}
+	if s.min > s.max {
+		return fmt.Errorf("minimum number of %s cannot be greater than the maximum", name)
+	}
+	return nil
+}
+
+type generateOptions struct {
+	paragraphs            int
+	sentencesPerParagraph span
+	wordsPerSentence      span
+	vocabularySize        int
+	functionWords         int
+	wordOptions           []aslanwords.GeneratorOption
+	seed                  *uint64
+}
+
+func newGenerateOptions() *generateOptions {
+	return &generateOptions{
+		paragraphs:            1,
+		sentencesPerParagraph: span{min: 3, max: 6},
+		wordsPerSentence:      span{min: 4, max: 12},
+		vocabularySize:        200,
+		functionWords:         12,
+	}
+}
+
+func (o *generateOptions) validate() error {
+	if o.paragraphs < 1 {
+		return fmt.Errorf("number of paragraphs must be one or greater")
+	}
+	if err := o.sentencesPerParagraph.validate("sentences per paragraph"); err != nil {
+		return err
+	}
+	if err := o.wordsPerSentence.validate("words per sentence"); err != nil {
+		return err
+	}
+	if o.vocabularySize < 1 {
+		return fmt.Errorf("vocabulary size must be one or greater")
+	}
+	if o.functionWords < 0 {
+		return fmt.Errorf("number of function words cannot be negative")
+	}
+	if o.functionWords > o.vocabularySize {
+		return fmt.Errorf("number of function words cannot be greater than the vocabulary size")
+	}
+	return nil
+}
+
+// WithParagraphs sets the number of paragraphs of the text, one by default
+func WithParagraphs(n int) Option {
+	return func(o *generateOptions) {
+		o.paragraphs = n
+	}
+}
+
+// WithSentencesPerParagraph sets the range of the number of sentences of each paragraph, both included. From 3 to 6 by default.
+func WithSentencesPerParagraph(min, max int) Option {
+	return func(o *generateOptions) {
+		o.sentencesPerParagraph = span{min: min, max: max}
+	}
+}
+
+// WithWordsPerSentence sets the range of the number of words of each sentence, both included. From 4 to 12 by default.
+func WithWordsPerSentence(min, max int) Option {
+	return func(o *generateOptions) {
+		o.wordsPerSentence = span{min: min, max: max}
+	}
+}
+
+// WithVocabularySize sets the number of different words the text is made of, 200 by default
+func WithVocabularySize(n int) Option {
+	return func(o *generateOptions) {
+		o.vocabularySize = n
+	}
+}
+
+// WithFunctionWords sets how many words of the vocabulary are function words, the most used ones, 12 by default.
+// Function words have a single syllable.
+func WithFunctionWords(n int) Option {
+	return func(o *generateOptions) {
+		o.functionWords = n
+	}
+}
+
+// WithWordOptions sets the options to generate the words of the vocabulary, like aslanwords.WithProfile.
+// The words that are not function words have from 2 to 4 syllables unless these options say otherwise.
+func WithWordOptions(opts ...aslanwords.GeneratorOption) Option {
+	return func(o *generateOptions) {
+		o.wordOptions = append(o.wordOptions, opts...)
+	}
+}
+
+// WithSeed makes the generation reproducible: the same seed with the same options will always generate the same text
+func WithSeed(seed uint64) Option {
+	return func(o *generateOptions) {
+		o.seed = &seed
+	}
+}
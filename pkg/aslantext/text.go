@@ -0,0 +1,137 @@
+package aslantext
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+)
+
+const (
+	// commaChance is the chance of a comma after a word that is not the last one of its sentence
+	commaChance = 0.08
+	// questionChance and exclamationChance are the chances of a sentence ending with a question or exclamation mark
+	questionChance    = 0.1
+	exclamationChance = 0.05
+)
+
+// Generate generates paragraphs of Aslan text with the given options, separated by blank lines.
+// If no options are provided, it generates a paragraph of 3 to 6 sentences of 4 to 12 words each.
+func Generate(ctx context.Context, opts ...Option) (string, error) {
+	options := newGenerateOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	if err := options.validate(); err != nil {
+		return "", fmt.Errorf("invalid options: %w", err)
+	}
+
+	seed := rand.Uint64()
+	if options.seed != nil {
+		seed = *options.seed
+	}
+	random := rand.New(rand.NewPCG(seed, seed))
+	vocabulary, err := newVocabulary(ctx, options, random)
+	if err != nil {
+		return "", err
+	}
+
+	paragraphs := make([]string, options.paragraphs)
+	for i := range paragraphs {
+		sentences := make([]string, between(random, options.sentencesPerParagraph))
+		for j := range sentences {
+			sentences[j] = sentence(random, vocabulary, between(random, options.wordsPerSentence))
+		}
+		paragraphs[i] = strings.Join(sentences, " ")
+	}
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+// vocabulary is the words of the text sorted by rank, along with the cumulative chance of picking each one
+type vocabulary struct {
+	words       []string
+	accumulated []float64
+}
+
+// newVocabulary generates the function words followed by the rest of the words, every one different from the others
+func newVocabulary(ctx context.Context, options *generateOptions, random *rand.Rand) (vocabulary, error) {
+	// each GenerateN call reads its own source, so both lists of words depend only on the seed
+	functionSource := aslanwords.WithRandSource(rand.NewPCG(random.Uint64(), random.Uint64()))
+	contentSource := aslanwords.WithRandSource(rand.NewPCG(random.Uint64(), random.Uint64()))
+	functionOptions := append(slices.Clone(options.wordOptions), aslanwords.WithNumberOfSyllables(1), functionSource)
+	words, err := aslanwords.GenerateN(ctx, options.functionWords, functionOptions...)
+	if err != nil {
+		return vocabulary{}, fmt.Errorf("unable to generate the function words: %w", err)
+	}
+
+	// the words are styled with the word options, while the excluded ones are not, so they are compared by their letters
+	functionWords := make(map[string]bool, len(words))
+	for _, word := range words {
+		functionWords[aslanwords.Spelling(word)] = true
+	}
+	contentOptions := append([]aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllablesBetween(2, 5)}, options.wordOptions...)
+	contentOptions = append(contentOptions, aslanwords.WithExclude(func(word string) bool { return functionWords[aslanwords.Spelling(word)] }), contentSource)
+	contentWords, err := aslanwords.GenerateN(ctx, options.vocabularySize-options.functionWords, contentOptions...)
+	if err != nil {
+		return vocabulary{}, fmt.Errorf("unable to generate the vocabulary: %w", err)
+	}
+	words = append(words, contentWords...)
+
+	// Zipf's law: the chance of each word is inversely proportional to its rank
+	v := vocabulary{words: words, accumulated: make([]float64, len(words))}
+	total := 0.0
+	for i := range words {
+		total += 1 / float64(i+1)
+		v.accumulated[i] = total
+	}
+	for i := range v.accumulated {
+		v.accumulated[i] /= total
+	}
+	return v, nil
+}
+
+// pick returns a random word, the higher its rank the more likely
+func (v vocabulary) pick(random *rand.Rand) string {
+	i, _ := slices.BinarySearch(v.accumulated, random.Float64())
+	return v.words[min(i, len(v.words)-1)]
+}
+
+// sentence returns a sentence of the given number of words starting with a capital letter and ending with a punctuation mark
+func sentence(random *rand.Rand, v vocabulary, numberOfWords int) string {
+	var text strings.Builder
+	for i := range numberOfWords {
+		word := v.pick(random)
+		if i == 0 {
+			word = capitalized(word)
+		} else {
+			text.WriteString(" ")
+		}
+		text.WriteString(word)
+		if i < numberOfWords-1 && random.Float64() < commaChance {
+			text.WriteString(",")
+		}
+	}
+	switch chance := random.Float64(); {
+	case chance < questionChance:
+		text.WriteString("?")
+	case chance < questionChance+exclamationChance:
+		text.WriteString("!")
+	default:
+		text.WriteString(".")
+	}
+	return text.String()
+}
+
+func capitalized(word string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(first)) + word[size:]
+}
+
+func between(random *rand.Rand, s span) int {
+	return s.min + random.IntN(s.max-s.min+1)
+}
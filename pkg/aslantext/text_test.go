@@ -0,0 +1,139 @@
+package aslantext_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslantext"
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sentencePattern = regexp.MustCompile(`[^.?!]+[.?!]`)
+
+func TestGenerate_by_default_it_should_generate_a_paragraph_of_sentences(t *testing.T) {
+	for seed := range uint64(10) {
+		text, err := aslantext.Generate(context.Background(), aslantext.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.NotContains(t, text, "\n")
+		sentences := sentencePattern.FindAllString(text, -1)
+		assert.GreaterOrEqual(t, len(sentences), 3)
+		assert.LessOrEqual(t, len(sentences), 6)
+		for _, sentence := range sentences {
+			assert.Regexp(t, `^[A-Z]`, strings.TrimSpace(sentence))
+			words := strings.Fields(sentence)
+			assert.GreaterOrEqual(t, len(words), 4)
+			assert.LessOrEqual(t, len(words), 12)
+		}
+	}
+}
+
+func TestGenerate_when_called_with_the_same_seed_it_should_always_generate_the_same_text(t *testing.T) {
+	ctx := context.Background()
+	expectedText, err := aslantext.Generate(ctx, aslantext.WithSeed(42), aslantext.WithParagraphs(3))
+	require.NoError(t, err)
+
+	for range 5 {
+		text, err := aslantext.Generate(ctx, aslantext.WithSeed(42), aslantext.WithParagraphs(3))
+		require.NoError(t, err)
+		assert.Equal(t, expectedText, text)
+	}
+}
+
+func TestGenerate_with_counts_it_should_generate_the_number_of_paragraphs_sentences_and_words(t *testing.T) {
+	text, err := aslantext.Generate(context.Background(),
+		aslantext.WithParagraphs(4), aslantext.WithSentencesPerParagraph(2, 2), aslantext.WithWordsPerSentence(5, 5))
+	require.NoError(t, err)
+
+	paragraphs := strings.Split(text, "\n\n")
+	require.Len(t, paragraphs, 4)
+	for _, paragraph := range paragraphs {
+		assert.Len(t, sentencePattern.FindAllString(paragraph, -1), 2)
+		assert.Len(t, strings.Fields(paragraph), 10)
+	}
+}
+
+func TestGenerate_function_words_should_be_the_most_used_ones(t *testing.T) {
+	text, err := aslantext.Generate(context.Background(), aslantext.WithSeed(7), aslantext.WithParagraphs(20),
+		aslantext.WithVocabularySize(50), aslantext.WithFunctionWords(5))
+	require.NoError(t, err)
+
+	counts := make(map[string]int)
+	total := 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return strings.ContainsRune(" ,.?!\n", r) }) {
+		counts[word]++
+		total++
+	}
+	assert.LessOrEqual(t, len(counts), 50)
+	mostUsed, times := "", 0
+	for word, count := range counts {
+		if count > times {
+			mostUsed, times = word, count
+		}
+	}
+	segmentations, err := aslanwords.Segment(mostUsed)
+	require.NoError(t, err)
+	assert.Len(t, segmentations[0].Syllables(), 1, "most used word %s should be a function word", mostUsed)
+	assert.Greater(t, float64(times)/float64(total), 0.1)
+}
+
+func TestGenerate_with_word_options_it_should_generate_the_vocabulary_with_them(t *testing.T) {
+	text, err := aslantext.Generate(context.Background(), aslantext.WithSeed(3),
+		aslantext.WithVocabularySize(1), aslantext.WithFunctionWords(0), aslantext.WithWordsPerSentence(3, 3),
+		aslantext.WithWordOptions(aslanwords.WithPrefix("hk"), aslanwords.WithNumberOfSyllables(3)))
+	require.NoError(t, err)
+
+	for _, sentence := range sentencePattern.FindAllString(text, -1) {
+		assert.Regexp(t, `^\s*Hk\S+,? hk\S+,? hk\S+[.?!]$`, sentence)
+	}
+}
+
+func TestGenerate_when_the_words_are_styled_the_vocabulary_should_not_repeat_the_function_words(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}, {Text: "e", Weight: 1}, {Text: "i", Weight: 1}, {Text: "o", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+	for seed := range uint64(10) {
+		// the phonology only has four words of one syllable, so the vocabulary must be all of them
+		text, err := aslantext.Generate(context.Background(), aslantext.WithSeed(seed), aslantext.WithParagraphs(10),
+			aslantext.WithVocabularySize(4), aslantext.WithFunctionWords(2),
+			aslantext.WithWordOptions(aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllables(1), aslanwords.WithCase(aslanwords.UpperCase)))
+		require.NoError(t, err)
+
+		words := make(map[string]bool)
+		for _, word := range strings.FieldsFunc(text, func(r rune) bool { return strings.ContainsRune(" ,.?!\n", r) }) {
+			words[strings.ToLower(word)] = true
+		}
+		assert.Len(t, words, 4, "seed %d", seed)
+	}
+}
+
+func TestGenerate_errors(t *testing.T) {
+	testCases := map[string]struct {
+		opts          []aslantext.Option
+		expectedError string
+	}{
+		"no paragraphs":           {[]aslantext.Option{aslantext.WithParagraphs(0)}, "number of paragraphs must be one or greater"},
+		"no sentences":            {[]aslantext.Option{aslantext.WithSentencesPerParagraph(0, 3)}, "minimum number of sentences per paragraph must be one or greater"},
+		"inverted sentences":      {[]aslantext.Option{aslantext.WithSentencesPerParagraph(4, 3)}, "minimum number of sentences per paragraph cannot be greater than the maximum"},
+		"no words":                {[]aslantext.Option{aslantext.WithWordsPerSentence(0, 3)}, "minimum number of words per sentence must be one or greater"},
+		"empty vocabulary":        {[]aslantext.Option{aslantext.WithVocabularySize(0)}, "vocabulary size must be one or greater"},
+		"negative function words": {[]aslantext.Option{aslantext.WithFunctionWords(-1)}, "number of function words cannot be negative"},
+		"too many function words": {[]aslantext.Option{aslantext.WithVocabularySize(5), aslantext.WithFunctionWords(6)}, "number of function words cannot be greater than the vocabulary size"},
+		"invalid word options":    {[]aslantext.Option{aslantext.WithWordOptions(aslanwords.WithProfile("droyne"))}, "unable to generate the function words"},
+		"vocabulary too big":      {[]aslantext.Option{aslantext.WithWordOptions(aslanwords.WithNumberOfSyllables(1), aslanwords.WithPrefix("hkoa")), aslantext.WithFunctionWords(0), aslantext.WithVocabularySize(100)}, "unable to generate the vocabulary"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslantext.Generate(context.Background(), tc.opts...)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
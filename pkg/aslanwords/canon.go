@@ -0,0 +1,59 @@
+package aslanwords
+
+import (
+	_ "embed"
+	"slices"
+	"strings"
+)
+
+// maxCanonDistance is the number of letters a word must differ from every canon word in to not resemble any of them
+const maxCanonDistance = 1
+
+//go:embed canon/aslan.txt
+var canonFile string
+
+var canonWords = parseCanon(canonFile)
+
+// parseCanon returns the lowercase words of the canon file sorted alphabetically, skipping blank lines and comments
+func parseCanon(content string) []string {
+	var words []string
+	for _, line := range strings.Split(content, "\n") {
+		word := strings.ToLower(strings.TrimSpace(line))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	slices.Sort(words)
+	return slices.Compact(words)
+}
+
+// Canon returns the lowercase canon Aslan words of the published material, like the names of clans and worlds,
+// sorted alphabetically
+func Canon() []string {
+	return slices.Clone(canonWords)
+}
+
+// IsCanon tells whether the word, ignoring the case of its letters, is a canon Aslan word
+func IsCanon(word string) bool {
+	_, found := slices.BinarySearch(canonWords, strings.ToLower(word))
+	return found
+}
+
+// WithAvoidCanon discards the words equal to a canon Aslan word or resembling one, that is, the ones turned into
+// a canon word by inserting, deleting or replacing a single letter
+func WithAvoidCanon() GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.constraints.avoidCanon = true
+	}
+}
+
+// resemblesCanon tells whether the lowercase word is a canon word or is too close to any of them
+func resemblesCanon(word string) bool {
+	for _, canon := range canonWords {
+		if editDistance([]rune(word), []rune(canon)) <= maxCanonDistance {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package aslanwords_test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCanon(t *testing.T) {
+	testCases := map[string]bool{
+		"Kusyu":       true,
+		"KHAUKHEAIRL": true,
+		"yerlyaruiwo": true,
+		"Kusyo":       false,
+		"":            false,
+	}
+	for word, expected := range testCases {
+		t.Run(word, func(t *testing.T) {
+			assert.Equal(t, expected, aslanwords.IsCanon(word))
+		})
+	}
+}
+
+func TestCanon_should_return_the_canon_words_sorted(t *testing.T) {
+	canon := aslanwords.Canon()
+
+	assert.True(t, slices.IsSorted(canon))
+	assert.Contains(t, canon, "kusyu")
+	canon[0] = "changed"
+	assert.NotContains(t, aslanwords.Canon(), "changed")
+}
+
+func TestCanonFile_should_be_sorted_alphabetically(t *testing.T) {
+	content, err := os.ReadFile("canon/aslan.txt")
+	require.NoError(t, err)
+
+	var words []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, strings.ToLower(line))
+		}
+	}
+	assert.True(t, slices.IsSorted(words), "the words of canon/aslan.txt should be sorted alphabetically")
+}
+
+func TestWithAvoidCanon(t *testing.T) {
+	testCases := map[string]struct {
+		onlyWord    string
+		shouldAvoid bool
+	}{
+		"canon word":                        {"Kusyu", true},
+		"canon word with a different case":  {"KuSyU", true},
+		"one letter added":                  {"Kusyua", true},
+		"one letter removed":                {"Kusy", true},
+		"one letter replaced":               {"Kusya", true},
+		"two letters away from a canon one": {"Kusyuaa", false},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// a model trained on a single word always generates that word
+			model, err := aslanwords.TrainMarkov([]string{tc.onlyWord}, 8)
+			require.NoError(t, err)
+
+			_, err = aslanwords.Generate(context.Background(), aslanwords.WithMarkov(model), aslanwords.WithAvoidCanon(), aslanwords.WithMaxAttemptsPerWord(3))
+
+			if tc.shouldAvoid {
+				assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithAvoidCanon_should_generate_words(t *testing.T) {
+	words, err := aslanwords.GenerateN(context.Background(), 50, aslanwords.WithAvoidCanon())
+	require.NoError(t, err)
+
+	for _, word := range words {
+		assert.False(t, aslanwords.IsCanon(word))
+	}
+}
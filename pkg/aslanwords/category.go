@@ -0,0 +1,276 @@
+package aslanwords
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+	"gopkg.in/yaml.v3"
+)
+
+// Names of the built-in categories
+const (
+	Ship        = "ship"
+	World       = "world"
+	Clan        = "clan"
+	Person      = "person"
+	Corporation = "corporation"
+)
+
+// ErrUnknownCategory is returned when the category used to generate words has not been registered
+var ErrUnknownCategory = errors.New("unknown category")
+
+//go:embed categories/builtin.yaml
+var builtinCategories []byte
+
+var categories = newCategoryRegistry()
+
+// Category is a preset of options to generate the names of a kind of entity, like starships or worlds,
+// so they sound alike between them and different from the names of other kinds
+type Category struct {
+	// Name identifies the category, it is not case-sensitive
+	Name string `json:"name" yaml:"name"`
+	// Profile is the language the words are generated for, aslan when empty
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// MinSyllables and MaxSyllables are the range of the number of syllables, both included, up to 13 syllables.
+	// When both are zero the default number of syllables is used.
+	MinSyllables int `json:"minSyllables,omitempty" yaml:"minSyllables,omitempty"`
+	MaxSyllables int `json:"maxSyllables,omitempty" yaml:"maxSyllables,omitempty"`
+	// Weights tweaks the phonology of the profile
+	Weights PhonologyWeights `json:"weights,omitzero" yaml:"weights,omitempty"`
+	// Case is the capitalization of the words: lower, title or upper. When lower, the case of the other options is kept.
+	Case Case `json:"case,omitempty" yaml:"case,omitempty"`
+	// SyllableSeparator is written between the syllables of the words. When empty, the separator of the other options is kept.
+	SyllableSeparator string `json:"syllableSeparator,omitempty" yaml:"syllableSeparator,omitempty"`
+}
+
+// PhonologyWeights replaces the weights of some consonants, vowels and kinds of syllable of a phonology.
+// Letters the phonology lacks are added to it, and letters with a weight of zero are removed.
+// The consonant weights also apply to the consonants of the Positions the phonology has.
+type PhonologyWeights struct {
+	FirstConsonants map[string]int `json:"firstConsonants,omitempty" yaml:"firstConsonants,omitempty"`
+	Vowels          map[string]int `json:"vowels,omitempty" yaml:"vowels,omitempty"`
+	LastConsonants  map[string]int `json:"lastConsonants,omitempty" yaml:"lastConsonants,omitempty"`
+	SyllableWeights map[string]int `json:"syllableWeights,omitempty" yaml:"syllableWeights,omitempty"`
+}
+
+func (w PhonologyWeights) isEmpty() bool {
+	return len(w.FirstConsonants) == 0 && len(w.Vowels) == 0 && len(w.LastConsonants) == 0 && len(w.SyllableWeights) == 0
+}
+
+// applyTo returns a copy of the phonology with the weights replaced
+func (w PhonologyWeights) applyTo(phonology Phonology) Phonology {
+	tweaked := phonology
+	tweaked.FirstConsonants = tweakPhonemes(phonology.FirstConsonants, w.FirstConsonants)
+	tweaked.Vowels = tweakPhonemes(phonology.Vowels, w.Vowels)
+	tweaked.LastConsonants = tweakPhonemes(phonology.LastConsonants, w.LastConsonants)
+	tweaked.Positions = Positions{
+		Initial: w.applyToPosition(phonology.Positions.Initial),
+		Medial:  w.applyToPosition(phonology.Positions.Medial),
+		Final:   w.applyToPosition(phonology.Positions.Final),
+	}
+	tweaked.SyllableWeights = make(map[string]int, len(phonology.SyllableWeights))
+	for key, weight := range phonology.SyllableWeights {
+		tweaked.SyllableWeights[key] = weight
+	}
+	for key, weight := range w.SyllableWeights {
+		tweaked.SyllableWeights[key] = weight
+	}
+	return tweaked
+}
+
+// applyToPosition returns a copy of the consonants of a position with the weights replaced.
+// Empty inventories are kept empty, so the position keeps using the consonants of the phonology.
+func (w PhonologyWeights) applyToPosition(consonants PositionalConsonants) PositionalConsonants {
+	if len(consonants.FirstConsonants) > 0 {
+		consonants.FirstConsonants = tweakPhonemes(consonants.FirstConsonants, w.FirstConsonants)
+	}
+	if len(consonants.LastConsonants) > 0 {
+		consonants.LastConsonants = tweakPhonemes(consonants.LastConsonants, w.LastConsonants)
+	}
+	return consonants
+}
+
+func tweakPhonemes(phonemes []Phoneme, weights map[string]int) []Phoneme {
+	tweaked := make([]Phoneme, 0, len(phonemes)+len(weights))
+	for _, phoneme := range phonemes {
+		if weight, ok := weights[phoneme.Text]; ok {
+			phoneme.Weight = weight
+		}
+		if phoneme.Weight != 0 {
+			tweaked = append(tweaked, phoneme)
+		}
+	}
+	var added []string
+	for text, weight := range weights {
+		if weight != 0 && !slices.ContainsFunc(phonemes, func(p Phoneme) bool { return p.Text == text }) {
+			added = append(added, text)
+		}
+	}
+	slices.Sort(added)
+	for _, text := range added {
+		tweaked = append(tweaked, Phoneme{Text: text, Weight: weights[text]})
+	}
+	return tweaked
+}
+
+// RegisterCategory makes the category available to WithCategory. Its name cannot be already registered,
+// its profile must be registered and its options must be valid.
+// The built-in categories are ship, world, clan, person and corporation.
+func RegisterCategory(category Category) error {
+	return categories.register(category)
+}
+
+// LookupCategory returns the registered category with the given name
+func LookupCategory(name string) (Category, bool) {
+	entry, ok := categories.lookup(name)
+	if !ok {
+		return Category{}, false
+	}
+	return entry.category, true
+}
+
+// Categories returns the names of the registered categories sorted alphabetically
+func Categories() []string {
+	return categories.names()
+}
+
+// LoadCategories reads a list of categories from a JSON or YAML file, depending on its extension.
+// They are not registered, use RegisterCategory for that.
+func LoadCategories(path string) ([]Category, error) {
+	unmarshal, err := fileUnmarshaler(path, "categories")
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the categories: %w", err)
+	}
+	return parseCategories(content, unmarshal)
+}
+
+func parseCategories(content []byte, unmarshal func([]byte, any) error) ([]Category, error) {
+	var loaded []Category
+	if err := unmarshal(content, &loaded); err != nil {
+		return nil, fmt.Errorf("unable to parse the categories: %w", err)
+	}
+	return loaded, nil
+}
+
+// WithCategory generates words with the options of the registered category with the given name: its profile,
+// number of syllables, weights and style. Options after it override the ones of the category.
+func WithCategory(name string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		entry, ok := categories.lookup(name)
+		if !ok {
+			o.categoryErr = fmt.Errorf("%w %q, it must be one of %s", ErrUnknownCategory, name, strings.Join(categories.names(), ", "))
+			return
+		}
+		o.categoryErr = nil
+		entry.apply(o)
+	}
+}
+
+type categoryEntry struct {
+	category     Category
+	phonotactics *phonotactics
+}
+
+func (e categoryEntry) apply(o *GeneratorOptions) {
+	o.phonologyErr = nil
+	o.phonotactics = e.phonotactics
+	if e.category.MinSyllables != 0 || e.category.MaxSyllables != 0 {
+		WithNumberOfSyllablesBetween(e.category.MinSyllables, e.category.MaxSyllables+1)(o)
+	}
+	if e.category.Case != LowerCase {
+		o.style.Case = e.category.Case
+	}
+	if e.category.SyllableSeparator != "" {
+		o.style.SyllableSeparator = e.category.SyllableSeparator
+	}
+}
+
+// categoryRegistry holds the categories by their lowercase name, compiling their tweaked phonology once on registration
+type categoryRegistry struct {
+	mu      sync.RWMutex
+	entries map[string]categoryEntry
+}
+
+func newCategoryRegistry() *categoryRegistry {
+	r := &categoryRegistry{entries: make(map[string]categoryEntry)}
+	builtin, err := parseCategories(builtinCategories, yaml.Unmarshal)
+	if err != nil {
+		panic(err)
+	}
+	for _, category := range builtin {
+		if err := r.register(category); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func (r *categoryRegistry) register(category Category) error {
+	if strings.TrimSpace(category.Name) == "" {
+		return errors.New("category must have a name")
+	}
+	profileName := category.Profile
+	if profileName == "" {
+		profileName = AslanProfile
+	}
+	profile, ok := profiles.lookup(profileName)
+	if !ok {
+		return fmt.Errorf("category %s: %w %q", category.Name, ErrUnknownProfile, profileName)
+	}
+	if category.MinSyllables > category.MaxSyllables {
+		return fmt.Errorf("category %s: min syllables cannot be greater than max syllables", category.Name)
+	}
+	if category.MaxSyllables > maxRandomSyllables {
+		return fmt.Errorf("category %s: max syllables cannot be greater than %d", category.Name, maxRandomSyllables)
+	}
+	entry := categoryEntry{category: category, phonotactics: profile.phonotactics}
+	if !category.Weights.isEmpty() {
+		rules, err := syllable.NewRules(category.Weights.applyTo(profile.profile.Phonology()))
+		if err != nil {
+			return fmt.Errorf("category %s: %w", category.Name, err)
+		}
+		entry.phonotactics = newPhonotactics(rules)
+	}
+	options := newGeneratorOptions()
+	entry.apply(options)
+	if err := options.Validate(); err != nil {
+		return fmt.Errorf("category %s: %w", category.Name, err)
+	}
+
+	key := strings.ToLower(category.Name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[key]; ok {
+		return fmt.Errorf("category %s is already registered", category.Name)
+	}
+	r.entries[key] = entry
+	return nil
+}
+
+func (r *categoryRegistry) lookup(name string) (categoryEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.entries[strings.ToLower(name)]
+	return entry, ok
+}
+
+func (r *categoryRegistry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for key := range r.entries {
+		names = append(names, key)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,223 @@
+package aslanwords_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategories_should_include_the_builtin_categories(t *testing.T) {
+	assert.Subset(t, aslanwords.Categories(), []string{aslanwords.Ship, aslanwords.World, aslanwords.Clan, aslanwords.Person, aslanwords.Corporation})
+}
+
+func TestWithCategory_builtin_categories_should_generate_words_with_their_number_of_syllables_and_case(t *testing.T) {
+	for _, name := range []string{aslanwords.Ship, aslanwords.World, aslanwords.Clan, aslanwords.Person, aslanwords.Corporation} {
+		t.Run(name, func(t *testing.T) {
+			category, ok := aslanwords.LookupCategory(name)
+			require.True(t, ok)
+			gen, err := aslanwords.New(aslanwords.WithCategory(name))
+			require.NoError(t, err)
+
+			for range 100 {
+				word, err := gen.GenerateWord(context.Background())
+				require.NoError(t, err)
+				// syllables whose letters are all collapsed are merged, so the word may have fewer syllables than generated
+				assert.LessOrEqual(t, len(word.Syllables()), category.MaxSyllables)
+				probability, err := aslanwords.Probability(word.String(), aslanwords.WithNumberOfSyllablesBetween(category.MinSyllables, category.MaxSyllables+1))
+				require.NoError(t, err)
+				assert.Positive(t, probability, "%s cannot have between %d and %d syllables", word, category.MinSyllables, category.MaxSyllables)
+				assert.Equal(t, word.Styled(aslanwords.Style{Case: category.Case}), gen.Styled(word))
+			}
+		})
+	}
+}
+
+func TestWithCategory_corporation_should_generate_uppercase_words(t *testing.T) {
+	word, err := aslanwords.Generate(context.Background(), aslanwords.WithCategory(aslanwords.Corporation))
+
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(word), word)
+}
+
+func TestWithCategory_options_after_it_should_override_the_ones_of_the_category(t *testing.T) {
+	gen, err := aslanwords.New(aslanwords.WithCategory(aslanwords.Ship), aslanwords.WithNumberOfSyllables(1), aslanwords.WithCase(aslanwords.LowerCase))
+	require.NoError(t, err)
+
+	for range 50 {
+		word, err := gen.GenerateWord(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, word.Syllables(), 1)
+		assert.Equal(t, word.String(), gen.Styled(word))
+	}
+}
+
+func TestWithCategory_when_the_category_has_no_style_it_should_keep_the_style_of_the_options_before_it(t *testing.T) {
+	require.NoError(t, aslanwords.RegisterCategory(aslanwords.Category{Name: "Moon", MinSyllables: 2, MaxSyllables: 2}))
+
+	gen, err := aslanwords.New(aslanwords.WithCase(aslanwords.UpperCase), aslanwords.WithSyllableSeparator("·"), aslanwords.WithCategory("moon"))
+	require.NoError(t, err)
+
+	word, err := gen.GenerateWord(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(strings.Join(word.Syllables(), "·")), gen.Styled(word))
+}
+
+func TestRegisterCategory_when_max_syllables_is_the_greatest_allowed_it_should_generate_words_of_that_length(t *testing.T) {
+	require.NoError(t, aslanwords.RegisterCategory(aslanwords.Category{Name: "Epic", MinSyllables: 13, MaxSyllables: 13}))
+
+	word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithCategory("epic"))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(word.Syllables()), 13)
+	probability, err := aslanwords.Probability(word.String(), aslanwords.WithNumberOfSyllables(13))
+	require.NoError(t, err)
+	assert.Positive(t, probability, "%s cannot be split into 13 syllables", word)
+}
+
+func TestWithCategory_when_the_category_is_unknown_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.New(aslanwords.WithCategory("spaceport"))
+
+	assert.ErrorIs(t, err, aslanwords.ErrUnknownCategory)
+}
+
+func TestRegisterCategory_should_make_a_homebrew_category_available(t *testing.T) {
+	category := aslanwords.Category{
+		Name:              "Outpost",
+		MinSyllables:      2,
+		MaxSyllables:      2,
+		Case:              aslanwords.TitleCase,
+		SyllableSeparator: "-",
+		Weights: aslanwords.PhonologyWeights{
+			FirstConsonants: map[string]int{"z": 1000},
+			SyllableWeights: map[string]int{"V": 0, "VC": 0, "CVC": 0},
+		},
+	}
+	require.NoError(t, aslanwords.RegisterCategory(category))
+
+	gen, err := aslanwords.New(aslanwords.WithCategory("outpost"))
+	require.NoError(t, err)
+
+	anyZ := false
+	for range 50 {
+		word, err := gen.GenerateWord(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CV", "CV"}, word.Keys())
+		assert.Regexp(t, "^[A-Z][a-z]+-[a-z]+$", gen.Styled(word))
+		anyZ = anyZ || strings.Contains(word.String(), "z")
+	}
+	assert.True(t, anyZ, "added consonant z has never been generated")
+	assert.Contains(t, aslanwords.Categories(), "outpost")
+}
+
+func TestRegisterCategory_when_the_profile_has_positions_the_weights_should_apply_to_their_consonants(t *testing.T) {
+	phonology := aslanwords.AslanPhonology()
+	phonology.Positions.Initial.FirstConsonants = []aslanwords.Phoneme{{Text: "kh", Weight: 3}, {Text: "ht", Weight: 2}}
+	require.NoError(t, aslanwords.RegisterProfile(aslanwords.NewProfile("Khtaoh", phonology)))
+	require.NoError(t, aslanwords.RegisterCategory(aslanwords.Category{
+		Name:    "Den",
+		Profile: "khtaoh",
+		Weights: aslanwords.PhonologyWeights{FirstConsonants: map[string]int{"kh": 0, "z": 1000}},
+	}))
+
+	gen, err := aslanwords.New(aslanwords.WithCategory("den"))
+	require.NoError(t, err)
+
+	anyZ := false
+	for range 100 {
+		word, err := gen.GenerateWord(context.Background())
+		require.NoError(t, err)
+		first := word.Parts()[0].FirstConsonant
+		assert.Contains(t, []string{"", "ht", "z"}, first, word.String())
+		anyZ = anyZ || first == "z"
+	}
+	assert.True(t, anyZ, "added consonant z has never started a word")
+}
+
+func TestRegisterCategory_errors(t *testing.T) {
+	testCases := map[string]struct {
+		category      aslanwords.Category
+		expectedError string
+	}{
+		"no name":            {aslanwords.Category{Name: " "}, "category must have a name"},
+		"already registered": {aslanwords.Category{Name: "Ship"}, "category Ship is already registered"},
+		"unknown profile":    {aslanwords.Category{Name: "droyne ship", Profile: "droyne"}, `category droyne ship: unknown profile "droyne"`},
+		"invalid weights": {
+			aslanwords.Category{Name: "mute", Weights: aslanwords.PhonologyWeights{Vowels: map[string]int{"a": -1}}},
+			`category mute: invalid phonology: invalid vowels: weight of phoneme "a" must be one or greater`,
+		},
+		"no min syllables": {
+			aslanwords.Category{Name: "endless", MaxSyllables: 2},
+			"category endless: minimum number of syllables must be one or greater",
+		},
+		"too many syllables": {
+			aslanwords.Category{Name: "litany", MinSyllables: 2, MaxSyllables: 14},
+			"category litany: max syllables cannot be greater than 13",
+		},
+		"invalid syllables": {
+			aslanwords.Category{Name: "backwards", MinSyllables: 3, MaxSyllables: 2},
+			"category backwards: min syllables cannot be greater than max syllables",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := aslanwords.RegisterCategory(tc.category)
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLoadCategories_it_should_read_the_categories_of_the_file(t *testing.T) {
+	expectedCategories := []aslanwords.Category{
+		{
+			Name:              "starport",
+			Profile:           "vilani",
+			MinSyllables:      2,
+			MaxSyllables:      3,
+			Case:              aslanwords.UpperCase,
+			SyllableSeparator: "-",
+			Weights:           aslanwords.PhonologyWeights{Vowels: map[string]int{"a": 20}},
+		},
+		{Name: "moon", MinSyllables: 1, MaxSyllables: 1},
+	}
+
+	for _, path := range []string{"testdata/categories.yaml", "testdata/categories.json"} {
+		t.Run(path, func(t *testing.T) {
+			loaded, err := aslanwords.LoadCategories(path)
+
+			require.NoError(t, err)
+			assert.Equal(t, expectedCategories, loaded)
+		})
+	}
+}
+
+func TestLoadCategories_errors(t *testing.T) {
+	testCases := map[string]struct {
+		path          string
+		expectedError string
+	}{
+		"missing file":          {"testdata/missing.yaml", "unable to read the categories"},
+		"malformed file":        {"testdata/malformed_categories.yaml", `unable to parse the categories: unknown case "shouting"`},
+		"unsupported extension": {"testdata/categories.toml", "unsupported categories file extension \".toml\""},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.LoadCategories(tc.path)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCategory_when_marshalled_to_JSON_without_weights_it_should_omit_them(t *testing.T) {
+	content, err := json.Marshal(aslanwords.Category{Name: "moon", MinSyllables: 2, MaxSyllables: 3})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"name":"moon","minSyllables":2,"maxSyllables":3}`, string(content))
+}
@@ -0,0 +1,209 @@
+package aslanwords
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// ErrCannotJoin is returned when two words or morphemes cannot be joined into a word that follows the rules of the language
+var ErrCannotJoin = errors.New("cannot be joined into a valid word")
+
+// Compound joins two words into a new one, like "hkoa" and "seas" into "hkoaseas", to build families of related terms.
+// When the end of the first word cannot be followed by the start of the second one, the junction is repaired the way the
+// generator does: the first vowel of the second word is swapped for one the swaps of vowels allow after the last vowel of the
+// first word, or else a syllable made of a vowel is inserted between them. The most likely vowels are tried first,
+// so the same words always give the same compound.
+// The syllables of both words are kept as they are, only the ones that meet at the junction are checked.
+// Only the profile or phonology of the options is used, Aslan by default.
+func Compound(a, b Word, opts ...GeneratorOption) (Word, error) {
+	p, err := joinPhonotactics(opts)
+	if err != nil {
+		return Word{}, err
+	}
+	if a.text == "" || b.text == "" {
+		return Word{}, errors.New("cannot compound an empty word")
+	}
+	return p.join(a, b)
+}
+
+// AddPrefix joins the prefix, a morpheme like "ao", before the word repairing the junction like Compound does
+func AddPrefix(prefix string, word Word, opts ...GeneratorOption) (Word, error) {
+	p, err := joinPhonotactics(opts)
+	if err != nil {
+		return Word{}, err
+	}
+	morpheme, err := p.morpheme(prefix)
+	if err != nil {
+		return Word{}, err
+	}
+	if word.text == "" {
+		return Word{}, errors.New("cannot add a prefix to an empty word")
+	}
+	return p.join(morpheme, word)
+}
+
+// AddSuffix joins the suffix, a morpheme like "lr", after the word repairing the junction like Compound does
+func AddSuffix(word Word, suffix string, opts ...GeneratorOption) (Word, error) {
+	p, err := joinPhonotactics(opts)
+	if err != nil {
+		return Word{}, err
+	}
+	morpheme, err := p.morpheme(suffix)
+	if err != nil {
+		return Word{}, err
+	}
+	if word.text == "" {
+		return Word{}, errors.New("cannot add a suffix to an empty word")
+	}
+	return p.join(word, morpheme)
+}
+
+func joinPhonotactics(opts []GeneratorOption) (*phonotactics, error) {
+	options := newGeneratorOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+	return options.phonotactics, nil
+}
+
+// morpheme returns the lowercase affix split into syllables if it can be, a morpheme like "lr" cannot be a word on its own
+func (p *phonotactics) morpheme(affix string) (Word, error) {
+	affix = strings.ToLower(strings.TrimSpace(affix))
+	if affix == "" {
+		return Word{}, errors.New("affix cannot be empty")
+	}
+	return p.Split(affix), nil
+}
+
+// join returns the word made of both words trying, in order, to join them as they are, to swap the first vowel of the
+// right one and to insert a vowel syllable between them. Only the syllables that meet at the junction are checked.
+func (p *phonotactics) join(left, right Word) (Word, error) {
+	if len(left.syllables) == 0 || len(right.syllables) == 0 {
+		return p.joinMorpheme(left, right)
+	}
+	last, first := left.syllables[len(left.syllables)-1], right.syllables[0]
+	if p.canFollow(last, first) {
+		if joined, ok := p.joined(left.syllables, right.syllables); ok {
+			return joined, nil
+		}
+	}
+	if strings.HasPrefix(first.Key, "V") {
+		for _, vowel := range likely(p.rules.NextVowels(last.Key, last.Vowel, first.Key)) {
+			swapped := slices.Clone(right.syllables)
+			swapped[0].Vowel = vowel
+			if vowel == first.Vowel || !p.canFollow(last, swapped[0]) || len(swapped) > 1 && !p.canFollow(swapped[0], swapped[1]) {
+				continue
+			}
+			if joined, ok := p.joined(left.syllables, swapped); ok {
+				return joined, nil
+			}
+		}
+	}
+	for _, vowel := range likely(p.rules.NextVowels(last.Key, last.Vowel, "V")) {
+		inserted := Syllable{Key: "V", Vowel: vowel}
+		if !p.canFollow(last, inserted) || !p.canFollow(inserted, first) {
+			continue
+		}
+		if joined, ok := p.joined(left.syllables, []Syllable{inserted}, right.syllables); ok {
+			return joined, nil
+		}
+	}
+	return Word{}, fmt.Errorf("%q and %q %w", left.text, right.text, ErrCannotJoin)
+}
+
+// joinMorpheme joins a word and a morpheme that cannot be split into syllables on its own, like "lr". The morpheme is split
+// along with the syllable of the word it meets, or with a vowel between them when they cannot be split together.
+func (p *phonotactics) joinMorpheme(left, right Word) (Word, error) {
+	var before, after []Syllable
+	leftText, rightText := left.text, right.text
+	if n := len(left.syllables); n > 0 {
+		before, leftText = left.syllables[:n-1], left.syllables[n-1].String()
+	}
+	if len(right.syllables) > 0 {
+		after, rightText = right.syllables[1:], right.syllables[0].String()
+	}
+	for _, vowel := range append([]string{""}, likely(p.rules.Vowels())...) {
+		junction := p.Split(leftText + vowel + rightText).syllables
+		if len(junction) == 0 ||
+			len(before) > 0 && !p.canFollow(before[len(before)-1], junction[0]) ||
+			len(after) > 0 && !p.canFollow(junction[len(junction)-1], after[0]) {
+			continue
+		}
+		if joined, ok := p.joined(before, junction, after); ok {
+			return joined, nil
+		}
+	}
+	return Word{}, fmt.Errorf("%q and %q %w", left.text, right.text, ErrCannotJoin)
+}
+
+// canFollow tells whether the syllable can be followed by the next one: the kind of the next one is one of its followers,
+// the letters where they meet would not be collapsed and the swaps of vowels allow the vowel of the next one
+func (p *phonotactics) canFollow(previous, next Syllable) bool {
+	if !p.rules.CanBeFollowedBy(previous.Key, next.Key) || p.rules.Collapses(previous.String(), next.String()) {
+		return false
+	}
+	_, ok := p.rules.NextVowels(previous.Key, previous.Vowel, next.Key)[next.Vowel]
+	return ok
+}
+
+// joined returns the word made of the syllables of the parts if the syllables that meet at their junctions, which are no
+// longer at the start or the end of a word, fit their new place and no letter of the word would be collapsed
+func (p *phonotactics) joined(parts ...[]Syllable) (Word, bool) {
+	var syllables []Syllable
+	var junctions []int
+	for _, part := range parts {
+		if len(part) == 0 {
+			continue
+		}
+		if len(syllables) > 0 {
+			junctions = append(junctions, len(syllables)-1, len(syllables))
+		}
+		syllables = append(syllables, part...)
+	}
+	var text strings.Builder
+	for _, s := range syllables {
+		text.WriteString(s.String())
+	}
+	// letters repeated across the junction may be repeated too many times to be generated
+	if collapse(text.String()) != text.String() {
+		return Word{}, false
+	}
+	for _, i := range junctions {
+		if !p.fitsSyllable(syllables[i], placeOf(i, len(syllables))) {
+			return Word{}, false
+		}
+	}
+	return Word{text: text.String(), syllables: syllables}, true
+}
+
+// fitsSyllable tells whether the consonants of the syllable can be the ones of a syllable at the given place of the word
+func (p *phonotactics) fitsSyllable(s Syllable, at place) bool {
+	if !p.positional {
+		return true
+	}
+	for kind, consonant := range map[syllable.SlotKind]string{
+		syllable.FirstConsonantSlot: s.FirstConsonant,
+		syllable.LastConsonantSlot:  s.LastConsonant,
+	} {
+		if _, ok := p.slotOptionsAt(kind, at)[consonant]; consonant != "" && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// likely returns the vowels from the most to the least likely, alphabetically when equally likely
+func likely(vowels map[string]int) []string {
+	return slices.SortedStableFunc(maps.Keys(vowels), func(a, b string) int {
+		return cmp.Or(cmp.Compare(vowels[b], vowels[a]), cmp.Compare(a, b))
+	})
+}
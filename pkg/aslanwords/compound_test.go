@@ -0,0 +1,143 @@
+package aslanwords_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompound(t *testing.T) {
+	testCases := map[string]struct {
+		a, b              string
+		expectedWord      string
+		expectedSyllables []string
+	}{
+		"when the words can follow each other it should join them": {
+			"hkoa", "seas", "hkoaseas", []string{"hkoa", "seas"},
+		},
+		"when both words meet at consonants it should insert a vowel syllable": {
+			"elaiw", "ftauhua", "elaiwaftauhua", []string{"el", "aiw", "a", "ftauh", "ua"},
+		},
+		"when both words meet at the same vowel it should swap the second one": {
+			"tukhua", "atea'", "tukhuaetea'", []string{"tukh", "ua", "e", "tea'"},
+		},
+		"when the vowels would be collapsed it should swap the second one": {
+			"iya", "aiai", "iyaeai", []string{"iy", "a", "e", "ai"},
+		},
+		"when both words meet at the same consonant it should not collapse them": {
+			"eikaw", "weawa", "eikawaweawa", []string{"ei", "kaw", "a", "weaw", "a"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			word, err := aslanwords.Compound(segmentedWord(t, tc.a), segmentedWord(t, tc.b))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWord, word.String())
+			assert.Equal(t, tc.expectedSyllables, word.Syllables())
+		})
+	}
+}
+
+func TestCompound_it_should_always_generate_valid_words(t *testing.T) {
+	ctx := context.Background()
+	for seed := range uint64(200) {
+		a, err := aslanwords.GenerateWord(ctx, aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+		b, err := aslanwords.GenerateWord(ctx, aslanwords.WithSeed(seed+1000))
+		require.NoError(t, err)
+
+		word, err := aslanwords.Compound(a, b)
+
+		require.NoError(t, err)
+		assert.NoError(t, aslanwords.Validate(word.String()))
+		assert.True(t, strings.HasPrefix(word.String(), a.String()), "%s should start with %s", word, a)
+		assert.Equal(t, a.Syllables(), word.Syllables()[:len(a.Syllables())], "syllables of %s should be kept", a)
+	}
+}
+
+func TestCompound_with_a_profile_it_should_follow_its_rules(t *testing.T) {
+	ctx := context.Background()
+	for seed := range uint64(50) {
+		a, err := aslanwords.GenerateWord(ctx, aslanwords.WithSeed(seed), aslanwords.WithProfile("vargr"))
+		require.NoError(t, err)
+		b, err := aslanwords.GenerateWord(ctx, aslanwords.WithSeed(seed+1000), aslanwords.WithProfile("vargr"))
+		require.NoError(t, err)
+
+		word, err := aslanwords.Compound(a, b, aslanwords.WithProfile("vargr"))
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, word.Syllables())
+		assert.True(t, strings.HasPrefix(word.String(), a.String()), "%s should start with %s", word, a)
+	}
+}
+
+func TestAddSuffix(t *testing.T) {
+	testCases := map[string]struct {
+		word, suffix string
+		expectedWord string
+	}{
+		"when the suffix can follow the word it should join them":       {"aiha", "lr", "aihalr"},
+		"when the suffix cannot follow the word it should insert vowel": {"eikaw", "lr", "eikawalr"},
+		"it should lowercase the suffix":                                {"hkoa", "SEAS", "hkoaseas"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			word, err := aslanwords.AddSuffix(segmentedWord(t, tc.word), tc.suffix)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWord, word.String())
+		})
+	}
+}
+
+func TestAddPrefix(t *testing.T) {
+	testCases := map[string]struct {
+		prefix, word string
+		expectedWord string
+	}{
+		"when the word can follow the prefix it should join them":       {"ao", "hkoa", "aohkoa"},
+		"when the word cannot follow the prefix it should insert vowel": {"ftahr", "seas", "ftahraseas"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			word, err := aslanwords.AddPrefix(tc.prefix, segmentedWord(t, tc.word))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedWord, word.String())
+		})
+	}
+}
+
+func TestCompound_errors(t *testing.T) {
+	hkoa := segmentedWord(t, "hkoa")
+	testCases := map[string]struct {
+		join          func() (aslanwords.Word, error)
+		expectedError string
+	}{
+		"empty word":      {func() (aslanwords.Word, error) { return aslanwords.Compound(hkoa, aslanwords.Word{}) }, "cannot compound an empty word"},
+		"empty prefix":    {func() (aslanwords.Word, error) { return aslanwords.AddPrefix(" ", hkoa) }, "affix cannot be empty"},
+		"empty suffix":    {func() (aslanwords.Word, error) { return aslanwords.AddSuffix(hkoa, "") }, "affix cannot be empty"},
+		"unknown letters": {func() (aslanwords.Word, error) { return aslanwords.AddSuffix(hkoa, "xyz") }, `"hkoa" and "xyz" cannot be joined into a valid word`},
+		"invalid options": {func() (aslanwords.Word, error) {
+			return aslanwords.Compound(hkoa, hkoa, aslanwords.WithProfile("droyne"))
+		}, "invalid options: unknown profile"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.join()
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestAddSuffix_when_the_suffix_cannot_be_joined_it_should_return_ErrCannotJoin(t *testing.T) {
+	_, err := aslanwords.AddSuffix(segmentedWord(t, "hkoa"), "xyz")
+
+	assert.ErrorIs(t, err, aslanwords.ErrCannotJoin)
+}
@@ -0,0 +1,194 @@
+package aslanwords
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// ErrUnsatisfiable is returned when no Aslan word can meet the constraints set in the options
+var ErrUnsatisfiable = errors.New("no aslan word can meet the constraints")
+
+// WithPrefix only generates words starting with the given letters, ignoring their case
+func WithPrefix(prefix string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.constraints.prefix = strings.ToLower(prefix)
+	}
+}
+
+// WithSuffix only generates words ending with the given letters, ignoring their case
+func WithSuffix(suffix string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.constraints.suffix = strings.ToLower(suffix)
+	}
+}
+
+// WithContains only generates words containing the given letters, ignoring their case.
+// It can be used many times to require several fragments.
+func WithContains(fragment string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.constraints.fragments = append(o.constraints.fragments, strings.ToLower(fragment))
+	}
+}
+
+// WithPattern only generates words matching the regular expression. Generated words are lowercase.
+// It can be used many times to require several patterns.
+func WithPattern(pattern *regexp.Regexp) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.constraints.patterns = append(o.constraints.patterns, pattern)
+	}
+}
+
+// WithExclude discards the generated words the function excludes, like the ones already given to something else.
+// It is called with the lowercase words. It can be used many times to exclude words of several sources.
+func WithExclude(exclude func(word string) bool) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.constraints.excludes = append(o.constraints.excludes, exclude)
+	}
+}
+
+// constraints are the conditions the generated words have to meet
+type constraints struct {
+	prefix     string
+	suffix     string
+	fragments  []string
+	patterns   []*regexp.Regexp
+	avoidCanon bool
+	excludes   []func(string) bool
+}
+
+// Validate checks the constraints can be met by some word of the phonotactics
+func (c constraints) Validate(p *phonotactics) error {
+	if !p.FragmentFits(c.prefix, true, false) {
+		return fmt.Errorf("%w: no word can start with %q", ErrUnsatisfiable, c.prefix)
+	}
+	if !p.FragmentFits(c.suffix, false, true) {
+		return fmt.Errorf("%w: no word can end with %q", ErrUnsatisfiable, c.suffix)
+	}
+	for _, fragment := range c.fragments {
+		if !p.FragmentFits(fragment, false, false) {
+			return fmt.Errorf("%w: no word can contain %q", ErrUnsatisfiable, fragment)
+		}
+	}
+	for _, pattern := range c.patterns {
+		if pattern == nil {
+			return fmt.Errorf("pattern cannot be nil")
+		}
+	}
+	for _, exclude := range c.excludes {
+		if exclude == nil {
+			return fmt.Errorf("exclude function cannot be nil")
+		}
+	}
+	return nil
+}
+
+// IsEmpty tells whether there is no constraint at all
+func (c constraints) IsEmpty() bool {
+	return c.prefix == "" && c.suffix == "" && len(c.fragments) == 0 && len(c.patterns) == 0 && !c.avoidCanon && len(c.excludes) == 0
+}
+
+// Unmet returns the first constraint the word does not meet, or an empty string when it meets them all
+func (c constraints) Unmet(word string) string {
+	if !strings.HasPrefix(word, c.prefix) {
+		return fmt.Sprintf("prefix %q", c.prefix)
+	}
+	if !strings.HasSuffix(word, c.suffix) {
+		return fmt.Sprintf("suffix %q", c.suffix)
+	}
+	for _, fragment := range c.fragments {
+		if !strings.Contains(word, fragment) {
+			return fmt.Sprintf("contains %q", fragment)
+		}
+	}
+	for _, pattern := range c.patterns {
+		if !pattern.MatchString(word) {
+			return fmt.Sprintf("pattern %q", pattern)
+		}
+	}
+	for _, exclude := range c.excludes {
+		if exclude(word) {
+			return "exclude"
+		}
+	}
+	if c.avoidCanon && resemblesCanon(word) {
+		return "avoid canon"
+	}
+	return ""
+}
+
+// Splits returns the ways the prefix and the suffix can be split into at most the given number of syllables
+func (c constraints) Splits(p *phonotactics, maxSyllables int) affixSplits {
+	return affixSplits{
+		prefix: p.fragmentSplits(c.prefix, true, maxSyllables),
+		suffix: p.fragmentSplits(c.suffix, false, maxSyllables),
+	}
+}
+
+// affixSplits are the ways the prefix and the suffix of the constraints can be split into syllables, sorted by their number of syllables
+type affixSplits struct {
+	prefix [][]fragmentSyllable
+	suffix [][]fragmentSyllable
+}
+
+// TemplateOptions returns the options that restrict the first syllables of a word with the given number of syllables
+// to the ones of a random split of the prefix, and its last syllables to the ones of a random split of the suffix
+// that does not overlap them, so fewer words have to be discarded
+func (s affixSplits) TemplateOptions(numberOfSyllables int, randomIntegerUpTo func(int) int) []syllable.TemplateOption {
+	var opts []syllable.TemplateOption
+	prefix := pickSplit(s.prefix, numberOfSyllables, randomIntegerUpTo)
+	if prefix != nil {
+		opts = append(opts, syllable.WithLeadingSyllables(restrictionsOf(prefix)...))
+	}
+	if suffix := pickSplit(s.suffix, numberOfSyllables-len(prefix), randomIntegerUpTo); suffix != nil {
+		opts = append(opts, syllable.WithTrailingSyllables(restrictionsOf(suffix)...))
+	}
+	return opts
+}
+
+// pickSplit returns a random split of the ones with at most the given number of syllables, or nil when there is none
+func pickSplit(splits [][]fragmentSyllable, maxSyllables int, randomIntegerUpTo func(int) int) []fragmentSyllable {
+	fitting, _ := slices.BinarySearchFunc(splits, maxSyllables+1, func(split []fragmentSyllable, length int) int {
+		return len(split) - length
+	})
+	if fitting == 0 {
+		return nil
+	}
+	return splits[randomIntegerUpTo(fitting)]
+}
+
+// restrictionsOf restricts each syllable to the shape and options of the syllable of the split.
+// The slots a split does not reach, before the start of a suffix, are left free.
+func restrictionsOf(split []fragmentSyllable) []syllable.SyllableRestriction {
+	restrictions := make([]syllable.SyllableRestriction, len(split))
+	for i, s := range split {
+		options := make([][]string, len(s.options))
+		for slot, option := range s.options {
+			if option != "" {
+				options[slot] = []string{option}
+			}
+		}
+		restrictions[i] = syllable.SyllableRestriction{Key: s.shape.Key, Options: options}
+	}
+	return restrictions
+}
+
+// UnmetConstraintError is returned when the generated words keep failing to meet a constraint
+// until the attempts allowed by WithMaxAttemptsPerWord run out. It matches ErrExhausted with errors.Is.
+type UnmetConstraintError struct {
+	// Constraint is the constraint most of the discarded words did not meet, like `prefix "kh"` or "content filter"
+	Constraint string
+	Attempts   int
+}
+
+func (e *UnmetConstraintError) Error() string {
+	return fmt.Sprintf("no word met the %s constraint after %d attempts", e.Constraint, e.Attempts)
+}
+
+func (e *UnmetConstraintError) Unwrap() error {
+	return ErrExhausted
+}
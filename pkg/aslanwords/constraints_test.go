@@ -0,0 +1,120 @@
+package aslanwords_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_with_constraints_should_only_generate_words_meeting_them(t *testing.T) {
+	testCases := map[string]struct {
+		option  aslanwords.GeneratorOption
+		matches func(string) bool
+	}{
+		"prefix starting with consonant": {aslanwords.WithPrefix("Kh"), func(w string) bool { return strings.HasPrefix(w, "kh") }},
+		"prefix starting with vowel":     {aslanwords.WithPrefix("ea"), func(w string) bool { return strings.HasPrefix(w, "ea") }},
+		"suffix ending with consonant":   {aslanwords.WithSuffix("'"), func(w string) bool { return strings.HasSuffix(w, "'") }},
+		"suffix ending with vowel":       {aslanwords.WithSuffix("ra"), func(w string) bool { return strings.HasSuffix(w, "ra") }},
+		"prefix of several syllables":    {aslanwords.WithPrefix("khtaoh"), func(w string) bool { return strings.HasPrefix(w, "khtaoh") }},
+		"suffix of several syllables":    {aslanwords.WithSuffix("eiyuao"), func(w string) bool { return strings.HasSuffix(w, "eiyuao") }},
+		"contains":                       {aslanwords.WithContains("iyr"), func(w string) bool { return strings.Contains(w, "iyr") }},
+		"pattern":                        {aslanwords.WithPattern(regexp.MustCompile(`^h.*s$`)), func(w string) bool { return strings.HasPrefix(w, "h") && strings.HasSuffix(w, "s") }},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gen, err := aslanwords.New(tc.option, aslanwords.WithSeed(1), aslanwords.WithNumberOfSyllablesBetween(2, 4))
+			require.NoError(t, err)
+
+			for range 50 {
+				word, err := gen.Generate(context.Background())
+				require.NoError(t, err)
+				assert.True(t, tc.matches(word), "%s does not meet the constraint", word)
+			}
+		})
+	}
+}
+
+func TestGenerate_when_constraints_cannot_be_met_it_should_fail_fast(t *testing.T) {
+	testCases := map[string]aslanwords.GeneratorOption{
+		"prefix with a letter not used by aslans":    aslanwords.WithPrefix("b"),
+		"prefix with invalid first consonant":        aslanwords.WithPrefix("tk"),
+		"suffix with invalid last consonant":         aslanwords.WithSuffix("ak"),
+		"suffix with a consonant that cannot be end": aslanwords.WithSuffix("ft"),
+		"contains consonants no syllables can join":  aslanwords.WithContains("kk"),
+		"contains a letter always collapsed":         aslanwords.WithContains("aa"),
+	}
+
+	for name, option := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.Generate(context.Background(), option)
+			assert.ErrorIs(t, err, aslanwords.ErrUnsatisfiable)
+		})
+	}
+}
+
+func TestGenerate_when_constraints_can_be_met_only_in_the_middle_of_syllables_it_should_not_fail(t *testing.T) {
+	testCases := map[string]aslanwords.GeneratorOption{
+		"contains the end of a consonant":      aslanwords.WithContains("ta"),
+		"contains the last and first of words": aslanwords.WithContains("'a"),
+		"suffix in the middle of a vowel":      aslanwords.WithSuffix("ya"),
+		"prefix with the start of a consonant": aslanwords.WithPrefix("kht"),
+	}
+
+	for name, option := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.New(option)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerate_when_pattern_cannot_be_matched_it_should_give_up(t *testing.T) {
+	_, err := aslanwords.Generate(context.Background(),
+		aslanwords.WithPattern(regexp.MustCompile(`x`)),
+		aslanwords.WithMaxAttemptsPerWord(20),
+	)
+	assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+}
+
+func TestGenerate_when_a_constraint_cannot_be_met_the_error_should_name_it(t *testing.T) {
+	_, err := aslanwords.Generate(context.Background(),
+		aslanwords.WithPrefix("kh"),
+		aslanwords.WithPattern(regexp.MustCompile(`x`)),
+		aslanwords.WithMaxAttemptsPerWord(20),
+	)
+
+	var unmet *aslanwords.UnmetConstraintError
+	require.ErrorAs(t, err, &unmet)
+	assert.Equal(t, `pattern "x"`, unmet.Constraint)
+	assert.EqualError(t, err, `no word met the pattern "x" constraint after 20 attempts`)
+}
+
+func TestGenerate_when_pattern_is_nil_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.Generate(context.Background(), aslanwords.WithPattern(nil))
+	assert.Error(t, err)
+}
+
+func TestGenerate_with_exclude_it_should_not_generate_the_excluded_words(t *testing.T) {
+	excluded := map[string]bool{}
+	ctx := context.Background()
+	for seed := range uint64(20) {
+		excluded[aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed), aslanwords.WithNumberOfSyllables(1))] = true
+	}
+	gen, err := aslanwords.New(aslanwords.WithNumberOfSyllables(1), aslanwords.WithExclude(func(word string) bool { return excluded[word] }))
+	require.NoError(t, err)
+
+	for range 200 {
+		assert.NotContains(t, excluded, mustGenerate(t, gen))
+	}
+}
+
+func TestGenerate_when_exclude_is_nil_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.Generate(context.Background(), aslanwords.WithExclude(nil))
+	assert.ErrorContains(t, err, "exclude function cannot be nil")
+}
@@ -0,0 +1,84 @@
+package aslanwords
+
+import (
+	_ "embed"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+//go:embed filter/denylist.txt
+var denylistFile string
+
+var builtinDenylist = parseDenylist(denylistFile)
+
+// WithContentFilter discards the words containing an offensive or embarrassing word, in English or other languages,
+// from the built-in denylist or from the given ones. Only letters are compared, ignoring their case and accents.
+// Generator.Rejections tells how many words have been discarded by the filter.
+func WithContentFilter(denied ...string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		if o.contentFilter == nil {
+			o.contentFilter = &contentFilter{denied: slices.Clone(builtinDenylist)}
+		}
+		o.contentFilter.denied = append(o.contentFilter.denied, parseDenylist(strings.Join(denied, "\n"))...)
+	}
+}
+
+// contentFilter holds the normalized words that cannot be part of a generated word
+type contentFilter struct {
+	denied []string
+}
+
+// Rejects tells whether the word contains any denied word
+func (f *contentFilter) Rejects(word string) bool {
+	if f == nil {
+		return false
+	}
+	normalized := normalizeForFilter(word)
+	for _, denied := range f.denied {
+		if strings.Contains(normalized, denied) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDenylist returns the normalized words of a denylist, skipping blank lines and comments
+func parseDenylist(content string) []string {
+	var words []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if word := normalizeForFilter(line); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// normalizeForFilter lowercases the letters of the text removing their accents and drops anything else,
+// so an apostrophe or an accent does not hide a denied word
+func normalizeForFilter(text string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if base, ok := withoutAccent[r]; ok {
+			normalized.WriteString(base)
+			continue
+		}
+		if unicode.IsLetter(r) {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+var withoutAccent = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ä': "a", 'ã': "a",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ó': "o", 'ò': "o", 'ô': "o", 'ö': "o", 'õ': "o",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u",
+	'ñ': "n", 'ç': "c", 'ß': "ss",
+}
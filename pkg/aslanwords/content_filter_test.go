@@ -0,0 +1,67 @@
+package aslanwords_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContentFilter(t *testing.T) {
+	testCases := map[string]struct {
+		onlyWord     string
+		denied       []string
+		shouldReject bool
+	}{
+		"word from the built-in denylist":        {"Kashita", nil, true},
+		"word from the given denylist":           {"Kusyu", []string{"SY"}, true},
+		"letters other than a to z are ignored":  {"Ku'syu", []string{"kusyu"}, true},
+		"accents of the denied word are ignored": {"Kusyu", []string{"kúsyu"}, true},
+		"word without denied words":              {"Kusyu", []string{"kh"}, false},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// a model trained on a single word always generates that word
+			model, err := aslanwords.TrainMarkov([]string{tc.onlyWord}, 8)
+			require.NoError(t, err)
+			gen, err := aslanwords.New(aslanwords.WithMarkov(model), aslanwords.WithContentFilter(tc.denied...), aslanwords.WithMaxAttemptsPerWord(3))
+			require.NoError(t, err)
+
+			_, err = gen.Generate(context.Background())
+
+			if tc.shouldReject {
+				assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+				assert.Equal(t, int64(3), gen.Rejections())
+			} else {
+				assert.NoError(t, err)
+				assert.Zero(t, gen.Rejections())
+			}
+		})
+	}
+}
+
+func TestWithContentFilter_should_not_change_the_words_when_none_is_rejected(t *testing.T) {
+	ctx := context.Background()
+	for seed := range uint64(20) {
+		gen, err := aslanwords.New(aslanwords.WithSeed(seed), aslanwords.WithContentFilter())
+		require.NoError(t, err)
+
+		word := mustGenerate(t, gen)
+
+		if gen.Rejections() == 0 {
+			assert.Equal(t, aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed)), word)
+		}
+	}
+}
+
+func TestGenerator_without_content_filter_it_should_not_reject_words(t *testing.T) {
+	model, err := aslanwords.TrainMarkov([]string{"Kashita"}, 8)
+	require.NoError(t, err)
+	gen, err := aslanwords.New(aslanwords.WithMarkov(model))
+	require.NoError(t, err)
+
+	assert.Equal(t, "kashita", mustGenerate(t, gen))
+	assert.Zero(t, gen.Rejections())
+}
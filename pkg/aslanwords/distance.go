@@ -0,0 +1,31 @@
+package aslanwords
+
+// editDistance returns the number of elements to insert, delete or replace to turn one sequence into the other
+func editDistance[T comparable](from, to []T) int {
+	previous := make([]int, len(to)+1)
+	current := make([]int, len(to)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := range from {
+		current[0] = i + 1
+		for j := range to {
+			replace := previous[j]
+			if from[i] != to[j] {
+				replace++
+			}
+			current[j+1] = min(previous[j+1]+1, current[j]+1, replace)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(to)]
+}
+
+// sharedPrefix returns the number of elements both sequences start with
+func sharedPrefix[T comparable](a, b []T) int {
+	shared := 0
+	for shared < len(a) && shared < len(b) && a[shared] == b[shared] {
+		shared++
+	}
+	return shared
+}
@@ -0,0 +1,59 @@
+package aslanwords_test
+
+import (
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	testCases := map[string]struct {
+		from     []string
+		to       []string
+		expected int
+	}{
+		"both empty":                    {nil, nil, 0},
+		"from empty":                    {nil, []string{"kh", "a"}, 2},
+		"to empty":                      {[]string{"kh", "a", "r"}, nil, 3},
+		"equal":                         {[]string{"hk", "o", "a"}, []string{"hk", "o", "a"}, 0},
+		"a sound replaced":              {[]string{"hk", "o"}, []string{"kh", "o"}, 1},
+		"a sound inserted":              {[]string{"hk", "o"}, []string{"hk", "o", "'"}, 1},
+		"a sound deleted":               {[]string{"t", "ea", "h"}, []string{"t", "h"}, 1},
+		"sounds sharing letters differ": {[]string{"k", "h", "a"}, []string{"kh", "a"}, 2},
+		"every sound replaced":          {[]string{"f", "a"}, []string{"w", "e"}, 2},
+		"replacements and an insertion": {[]string{"kh", "a", "r"}, []string{"t", "ai", "r", "l"}, 3},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, aslanwords.EditDistance(tc.from, tc.to))
+			assert.Equal(t, tc.expected, aslanwords.EditDistance(tc.to, tc.from))
+		})
+	}
+}
+
+func TestEditDistance_of_letters(t *testing.T) {
+	assert.Equal(t, 1, aslanwords.EditDistance([]rune("kusyu"), []rune("kusyo")))
+	assert.Equal(t, 2, aslanwords.EditDistance([]rune("hko"), []rune("kho")))
+}
+
+func TestSharedPrefix(t *testing.T) {
+	testCases := map[string]struct {
+		a        []string
+		b        []string
+		expected int
+	}{
+		"both empty":                    {nil, nil, 0},
+		"one empty":                     {nil, []string{"kh", "a"}, 0},
+		"equal":                         {[]string{"kh", "a", "r"}, []string{"kh", "a", "r"}, 3},
+		"one is the start of the other": {[]string{"kh", "a"}, []string{"kh", "a", "r"}, 2},
+		"different first sound":         {[]string{"kh", "a"}, []string{"k", "a"}, 0},
+		"sounds sharing letters differ": {[]string{"ht", "a"}, []string{"h", "ta"}, 0},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, aslanwords.SharedPrefix(tc.a, tc.b))
+			assert.Equal(t, tc.expected, aslanwords.SharedPrefix(tc.b, tc.a))
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package aslanwords
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// WeightedWord is a word along with the chance of generating it once the number of syllables has been chosen
+type WeightedWord struct {
+	Word
+	// Weight is the sum of the chances of every way of generating the word with the number of syllables
+	Weight float64
+}
+
+// String returns the syllables of the word separated by hyphens along with its weight, like "hko-a-seas (0.0001)"
+func (w WeightedWord) String() string {
+	return weightedString(w.Word, w.Weight)
+}
+
+// Format writes the word like String with the verbs s, v and q, and like Word.Format with the rest of verbs
+func (w WeightedWord) Format(f fmt.State, verb rune) {
+	formatWeighted(f, verb, w.Word, w.Weight)
+}
+
+// Enumerate returns every different word that can be generated with the given number of syllables, in alphabetical order.
+// Words are generated as the sequence is walked, so it can be used even when there are too many words to keep them in memory.
+// Their weights and splits are worked out along with their letters, a few microseconds per word: the 1.7 million words
+// of two Aslan syllables take seconds.
+// The syllables of each word are its most likely split. The number of syllables in the options is ignored.
+// The sequence ends when the loop is broken, every word has been yielded or, once the context is done, after yielding the context error.
+// If the options are invalid the only element of the sequence is the validation error.
+func Enumerate(ctx context.Context, numberOfSyllables int, opts ...GeneratorOption) iter.Seq2[WeightedWord, error] {
+	options := newGeneratorOptions()
+	for _, o := range append(slices.Clone(opts), WithNumberOfSyllables(numberOfSyllables)) {
+		o(options)
+	}
+	if err := options.Validate(); err != nil {
+		return func(yield func(WeightedWord, error) bool) {
+			yield(WeightedWord{}, fmt.Errorf("invalid options: %w", err))
+		}
+	}
+	if err := options.phonotactics.checkCountable(); err != nil {
+		return func(yield func(WeightedWord, error) bool) {
+			yield(WeightedWord{}, err)
+		}
+	}
+
+	c := newSpaceCounter(options.phonotactics, []int{numberOfSyllables})
+	return func(yield func(WeightedWord, error) bool) {
+		letters := make([]byte, 0, 32)
+		// walk returns false once the sequence must end
+		var walk func(set stateSet, weighted *weightedSet) bool
+		walk = func(set stateSet, weighted *weightedSet) bool {
+			if err := ctx.Err(); err != nil {
+				yield(WeightedWord{}, err)
+				return false
+			}
+			if set.isComplete && !yield(c.weightedWord(weighted, string(letters)), nil) {
+				return false
+			}
+			for _, letter := range c.letters {
+				next, ok := c.write(set, letter)
+				if !ok {
+					continue
+				}
+				letters = append(letters, letter)
+				if !walk(next, c.writeWeighted(weighted, letter, len(letters))) {
+					return false
+				}
+				letters = letters[:len(letters)-1]
+			}
+			return true
+		}
+		walk(c.start(), c.startWeighted())
+	}
+}
+
+// weightedSet is a stateSet that also carries, for every way of generating the letters written so far, the chance of
+// generating them as parseChart.Chance keeps it: one for the whole vowel table and one for each vowel swap. Ways reaching
+// the same state add up their chances, and only keep the splits that can still be the most likely one of the word.
+// Generation states are not mapped to their canonical slots because the shape of each syllable is part of its split.
+type weightedSet struct {
+	states []*weightedState
+	index  map[weightedKey]*weightedState
+	// weight is the chance of generating the letters written so far as a whole word
+	weight float64
+	// best is the most likely split of the letters as a whole word, preferring the ones where every vowel is written
+	best       *weightedSplit
+	bestWeight float64
+}
+
+// weightedKey is a generation state along with what its chance depends on: the length of the chain of vowels of its
+// syllable, see chanceKey, and whether the letters of its syllable or of the previous one have all been collapsed
+type weightedKey struct {
+	st       generationState
+	chain    int8
+	written  bool // a letter of the syllable has been written
+	vanished bool // the letters of the previous syllable have all been collapsed
+}
+
+type weightedState struct {
+	weightedKey
+	vectors []float64
+	splits  []*weightedSplit
+}
+
+// weightedSplit is a way of splitting the letters written so far along with the chance of generating it with each vowel table
+type weightedSplit struct {
+	vectors   []float64
+	allVowels bool
+	last      *slotMark
+}
+
+// slotMark is a slot finished by a split: its option and how many letters were written when it finished, along with the previous one
+type slotMark struct {
+	previous *slotMark
+	shape    int8
+	slot     int8
+	option   string
+	end      int
+}
+
+// startWeighted returns the weighted states before writing any letter
+func (c *spaceCounter) startWeighted() *weightedSet {
+	set := &weightedSet{index: make(map[weightedKey]*weightedState)}
+	start := make([]float64, 1+len(c.p.vowelSwaps))
+	for i := range start {
+		start[i] = 1
+	}
+	c.settleWeighted(set, weightedKey{st: generationState{shape: -1, tables: 1}}, start, []*weightedSplit{{vectors: start, allVowels: true}}, 0)
+	return set
+}
+
+// writeWeighted returns the weighted states reached by writing the letter after the letters of the set,
+// which are the given number of letters once it is written
+func (c *spaceCounter) writeWeighted(set *weightedSet, letter byte, letters int) *weightedSet {
+	next := &weightedSet{index: make(map[weightedKey]*weightedState)}
+	for _, ws := range set.states {
+		if c.isCollapsed(ws.st, letter) {
+			continue
+		}
+		st, ok := c.generate(ws.st, letter)
+		if !ok {
+			continue
+		}
+		key := ws.weightedKey
+		key.st, key.written = st, true
+		c.settleWeighted(next, key, ws.vectors, ws.splits, letters)
+	}
+	return next
+}
+
+// settleWeighted adds to the set the states reached from the given one without writing any letter, as settle does,
+// multiplying the chances by the chance of each option finished and each shape picked
+func (c *spaceCounter) settleWeighted(set *weightedSet, key weightedKey, vectors []float64, splits []*weightedSplit, letters int) {
+	st := key.st
+	if c.isEndOfSyllable(st) {
+		// a syllable whose letters are all collapsed could be repeated forever, only one is taken as parseChart does
+		if st.shape >= 0 && !key.written && key.vanished {
+			return
+		}
+		if st.shape >= 0 && c.endsWord(st) {
+			set.complete(c.p, key, vectors, splits)
+		}
+		c.nextWeightedSyllables(set, key, vectors, splits, letters)
+		return
+	}
+
+	node := c.trie(st).nodes[st.node]
+	if len(node.children) > 0 {
+		set.add(key, vectors, splits)
+	}
+	for _, letter := range node.letters {
+		if !c.isCollapsed(st, letter) {
+			continue
+		}
+		if next, ok := c.generate(st, letter); ok {
+			collapsed := key
+			collapsed.st = next
+			c.settleWeighted(set, collapsed, vectors, splits, letters)
+		}
+	}
+	if node.optionTables&1 == 0 {
+		return
+	}
+	kind := c.p.shapes[st.shape].Slots[st.slot]
+	at := place{first: st.syllables == 1, last: st.final}
+	// the vowel after a chain of odd length is picked from the next vowels of the swap, see parseChart.advance
+	previousChain := 0
+	if kind == syllable.VowelSlot && key.chain > 1 {
+		previousChain = int(key.chain) - 1
+	}
+	chances := c.optionChances(kind, at, previousChain, node.text)
+	finish := func(vectors []float64) []float64 {
+		next := make([]float64, len(vectors))
+		for table, chance := range vectors {
+			next[table] = chance * chances[table]
+		}
+		return next
+	}
+	finished := key
+	finished.st.slot++
+	finished.st.node = 0
+	finishedSplits := make([]*weightedSplit, len(splits))
+	for i, split := range splits {
+		mark := &slotMark{previous: split.last, shape: st.shape, slot: st.slot, option: node.text, end: letters}
+		allVowels := split.allVowels && (kind != syllable.VowelSlot || letters > mark.start())
+		finishedSplits[i] = &weightedSplit{vectors: finish(split.vectors), allVowels: allVowels, last: mark}
+	}
+	c.settleWeighted(set, finished, finish(vectors), finishedSplits, letters)
+}
+
+// nextWeightedSyllables adds to the set the starts of the syllables that can follow the ended one, as nextSyllables does,
+// multiplying the chances by the chance of picking their shape and, unless they go on with its chain of vowels, of generating the chain
+func (c *spaceCounter) nextWeightedSyllables(set *weightedSet, ended weightedKey, vectors []float64, splits []*weightedSplit, letters int) {
+	if int(ended.st.syllables) >= c.maxSyllables || ended.st.final {
+		return
+	}
+	previousKey := ""
+	if ended.st.shape >= 0 {
+		previousKey = c.p.shapes[ended.st.shape].Key
+	}
+	for i, shape := range c.p.shapes {
+		if shape.Weight == 0 || previousKey != "" && !c.p.rules.CanBeFollowedBy(previousKey, shape.Key) {
+			continue
+		}
+		shapeChance := c.p.shapeChance(previousKey, shape)
+		key := weightedKey{st: ended.st, chain: 1, vanished: ended.st.shape >= 0 && !ended.written}
+		key.st.syllables++
+		key.st.shape, key.st.slot, key.st.node = int8(i), 0, 0
+		continuesChain := ended.st.shape >= 0 && endsWithVowel(c.p.shapes[ended.st.shape]) && shape.Slots[0] == syllable.VowelSlot
+		if continuesChain {
+			key.chain = int8(nextChain(int(ended.chain)))
+		}
+		start := func(vectors []float64) []float64 {
+			next := make([]float64, len(vectors))
+			if continuesChain {
+				for table, chance := range vectors {
+					next[table] = shapeChance * chance
+				}
+				return next
+			}
+			closed := shapeChance * c.p.chainChance(vectors, int(ended.chain))
+			for table := range next {
+				next[table] = closed
+			}
+			return next
+		}
+		started := make([]*weightedSplit, len(splits))
+		for j, split := range splits {
+			started[j] = &weightedSplit{vectors: start(split.vectors), allVowels: split.allVowels, last: split.last}
+		}
+		for _, placed := range c.placed(key.st) {
+			key.st = placed
+			c.settleWeighted(set, key, start(vectors), started, letters)
+		}
+	}
+}
+
+// optionChances returns the chance of picking the option of a slot of the given kind at the place of the word with each
+// vowel table, after a chain of vowels of the given length when it is a vowel. They are kept to be reused by the next words.
+func (c *spaceCounter) optionChances(kind syllable.SlotKind, at place, previousChain int, option string) []float64 {
+	if kind == syllable.VowelSlot {
+		at = place{}
+	} else {
+		previousChain = 0
+	}
+	key := optionChanceKey{kind: kind, at: at, chain: previousChain, option: option}
+	if chances, ok := c.chances[key]; ok {
+		return chances
+	}
+	chances := make([]float64, 1+len(c.p.vowelSwaps))
+	for table := range chances {
+		if kind == syllable.VowelSlot {
+			chances[table] = c.p.vowelTable(table, previousChain).chance(option)
+		} else {
+			chances[table] = c.p.slotOptionsAt(kind, at).chance(option)
+		}
+	}
+	c.chances[key] = chances
+	return chances
+}
+
+// add adds the chances and splits of a way of reaching the state to the ones already in the set
+func (s *weightedSet) add(key weightedKey, vectors []float64, splits []*weightedSplit) {
+	if slices.Max(vectors) == 0 {
+		return
+	}
+	ws, ok := s.index[key]
+	if !ok {
+		ws = &weightedState{weightedKey: key, vectors: make([]float64, len(vectors))}
+		s.index[key] = ws
+		s.states = append(s.states, ws)
+	}
+	for table, chance := range vectors {
+		ws.vectors[table] += chance
+	}
+	for _, split := range splits {
+		ws.splits = withSplit(ws.splits, split)
+	}
+}
+
+// withSplit adds the split to the splits reaching a state unless another one is at least as likely with every vowel table
+// and writes every vowel if it does, removing the ones it is such a split for. The chance of every way of going on from
+// a state is a sum of its chances with each table times the same factors, so those splits can never be the most likely.
+func withSplit(splits []*weightedSplit, split *weightedSplit) []*weightedSplit {
+	if slices.Max(split.vectors) == 0 {
+		return splits
+	}
+	for _, other := range splits {
+		if other.dominates(split) {
+			return splits
+		}
+	}
+	splits = slices.DeleteFunc(splits, split.dominates)
+	return append(splits, split)
+}
+
+func (s *weightedSplit) dominates(other *weightedSplit) bool {
+	if other.allVowels && !s.allVowels {
+		return false
+	}
+	for table, chance := range s.vectors {
+		if chance < other.vectors[table] {
+			return false
+		}
+	}
+	return true
+}
+
+// complete adds the chances and splits of a way of generating the letters as a whole word
+func (s *weightedSet) complete(p *phonotactics, key weightedKey, vectors []float64, splits []*weightedSplit) {
+	s.weight += p.chainChance(vectors, int(key.chain))
+	for _, split := range splits {
+		weight := p.chainChance(split.vectors, int(key.chain))
+		if weight == 0 {
+			continue
+		}
+		if s.best == nil || split.allVowels && !s.best.allVowels || split.allVowels == s.best.allVowels && weight > s.bestWeight {
+			s.best, s.bestWeight = split, weight
+		}
+	}
+}
+
+// weightedWord returns the letters of a complete word of the set split into their most likely syllables along with their chance
+func (c *spaceCounter) weightedWord(set *weightedSet, word string) WeightedWord {
+	split := Word{text: word}
+	if set.best != nil {
+		split = set.best.parse(c.p, word).Word()
+	}
+	return WeightedWord{Word: split, Weight: set.weight}
+}
+
+// start returns how many letters were written when the slot started
+func (m *slotMark) start() int {
+	if m.previous == nil {
+		return 0
+	}
+	return m.previous.end
+}
+
+// parse returns the syllables of the split of the word
+func (s *weightedSplit) parse(p *phonotactics, word string) parse {
+	marks := make([]*slotMark, 0, 3*len(word))
+	for mark := s.last; mark != nil; mark = mark.previous {
+		marks = append(marks, mark)
+	}
+	var found parse
+	for _, mark := range slices.Backward(marks) {
+		if mark.slot == 0 {
+			shape := p.shapes[mark.shape]
+			found = append(found, parsedSyllable{
+				shape:   shape,
+				options: make([]string, 0, len(shape.Slots)),
+				written: make([]string, 0, len(shape.Slots)),
+			})
+		}
+		last := &found[len(found)-1]
+		last.options = append(last.options, mark.option)
+		last.written = append(last.written, word[mark.start():mark.end])
+	}
+	return found
+}
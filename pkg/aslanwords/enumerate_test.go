@@ -0,0 +1,181 @@
+package aslanwords_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumerate_should_yield_every_different_word_once_with_its_weight(t *testing.T) {
+	var words []string
+	totalWeight := 0.0
+	for word, err := range aslanwords.Enumerate(context.Background(), 1) {
+		require.NoError(t, err)
+		words = append(words, word.Word.String())
+		totalWeight += word.Weight
+	}
+
+	assert.Len(t, words, 3960)
+	assert.True(t, slices.IsSorted(words), "words should be sorted")
+	assert.Len(t, slices.Compact(slices.Clone(words)), len(words))
+	assert.InDelta(t, 1, totalWeight, 1e-9)
+}
+
+func TestEnumerate_words_should_follow_the_rules(t *testing.T) {
+	count := 0
+	for word, err := range aslanwords.Enumerate(context.Background(), 3) {
+		require.NoError(t, err)
+		require.NoError(t, aslanwords.Validate(word.Word.String()))
+		assert.Len(t, word.Syllables(), 3)
+		assert.Equal(t, word.Word.String(), strings.Join(word.Syllables(), ""))
+		assert.Positive(t, word.Weight)
+		count++
+		if count == 2000 {
+			break
+		}
+	}
+	assert.Equal(t, 2000, count)
+}
+
+func TestEnumerate_weight_should_be_the_probability_of_the_word(t *testing.T) {
+	for _, profile := range []string{"aslan", "vargr"} {
+		t.Run(profile, func(t *testing.T) {
+			count := 0
+			for word, err := range aslanwords.Enumerate(context.Background(), 2, aslanwords.WithProfile(profile)) {
+				require.NoError(t, err)
+				probability, err := aslanwords.Probability(word.Word.String(), aslanwords.WithProfile(profile), aslanwords.WithNumberOfSyllables(2))
+				require.NoError(t, err)
+
+				assert.InDelta(t, probability, word.Weight, 1e-12, word.Word.String())
+				count++
+				if count == 500 {
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestEnumerate_weight_should_add_up_the_ways_of_generating_a_word_where_a_letter_is_collapsed_after_two_repetitions(t *testing.T) {
+	// "aell" and "laek" are generated as "aelllaek", whose third "l" is collapsed
+	for word, err := range aslanwords.Enumerate(context.Background(), 2, aslanwords.WithProfile("vargr")) {
+		require.NoError(t, err)
+		if word.Word.String() != "aellaek" {
+			continue
+		}
+		probability, err := aslanwords.Probability("aellaek", aslanwords.WithProfile("vargr"), aslanwords.WithNumberOfSyllables(2))
+		require.NoError(t, err)
+
+		assert.InDelta(t, probability, word.Weight, 1e-15)
+		return
+	}
+	t.Fatal("aellaek should be enumerated")
+}
+
+func TestEnumerate_syllables_should_be_the_most_likely_split(t *testing.T) {
+	count := 0
+	for word, err := range aslanwords.Enumerate(context.Background(), 2) {
+		require.NoError(t, err)
+		segmentations, err := aslanwords.Segment(word.Word.String())
+		require.NoError(t, err)
+
+		best := 0.0
+		for _, segmentation := range segmentations {
+			if len(segmentation.Syllables()) == len(word.Syllables()) {
+				best = max(best, segmentation.Weight)
+			}
+		}
+		i := slices.IndexFunc(segmentations, func(segmentation aslanwords.Segmentation) bool {
+			return slices.Equal(segmentation.Syllables(), word.Syllables()) && slices.Equal(segmentation.Keys(), word.Keys())
+		})
+		require.NotEqual(t, -1, i, word.Word.String())
+		assert.InEpsilon(t, best, segmentations[i].Weight, 1e-9, word.Word.String())
+		count++
+		if count == 500 {
+			break
+		}
+	}
+}
+
+func TestEnumerate_should_use_the_phonology_of_the_options(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}, {Text: "e", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+
+	var words []string
+	for word, err := range aslanwords.Enumerate(context.Background(), 2, aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllables(5)) {
+		require.NoError(t, err)
+		words = append(words, word.Word.String())
+	}
+
+	assert.Equal(t, []string{"kaka", "kake", "keka", "keke"}, words)
+}
+
+func TestEnumerate_when_context_is_cancelled_it_should_yield_the_context_error_and_stop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var errs []error
+	count := 0
+	for _, err := range aslanwords.Enumerate(ctx, 3) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, 10, count)
+	assert.Equal(t, []error{context.Canceled}, errs)
+}
+
+func TestEnumerate_when_the_number_of_syllables_is_invalid_it_should_only_yield_the_error(t *testing.T) {
+	var errs []error
+	for word, err := range aslanwords.Enumerate(context.Background(), 0) {
+		assert.Empty(t, word.Word.String())
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "invalid options")
+}
+
+func TestEnumerate_should_not_write_into_the_options_of_the_caller(t *testing.T) {
+	opts := make([]aslanwords.GeneratorOption, 1, 2)
+	opts[0] = aslanwords.WithProfile("vargr")
+
+	for _, err := range aslanwords.Enumerate(context.Background(), 1, opts...) {
+		require.NoError(t, err)
+		break
+	}
+
+	assert.Nil(t, opts[:2][1])
+}
+
+func TestWeightedWord_when_printed_it_should_show_the_syllables_and_the_weight(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}, {Text: "e", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+
+	for word, err := range aslanwords.Enumerate(context.Background(), 2, aslanwords.WithPhonology(phonology)) {
+		require.NoError(t, err)
+		assert.Equal(t, "ka-ka (0.25)", fmt.Sprint(word))
+		assert.Equal(t, `"ka-ka (0.25)"`, fmt.Sprintf("%q", word))
+		assert.Equal(t, "Kaka", fmt.Sprintf("%t", word))
+		break
+	}
+}
@@ -0,0 +1,11 @@
+package aslanwords
+
+// EditDistance exposes editDistance to the tests
+func EditDistance[T comparable](from, to []T) int {
+	return editDistance(from, to)
+}
+
+// SharedPrefix exposes sharedPrefix to the tests
+func SharedPrefix[T comparable](a, b []T) int {
+	return sharedPrefix(a, b)
+}
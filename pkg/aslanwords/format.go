@@ -0,0 +1,180 @@
+package aslanwords
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case is how the letters of a word are capitalized
+type Case int
+
+const (
+	// LowerCase writes every letter in lowercase, as words are generated
+	LowerCase Case = iota
+	// TitleCase writes the first letter in uppercase and the rest in lowercase
+	TitleCase
+	// UpperCase writes every letter in uppercase
+	UpperCase
+)
+
+var caseNames = []string{LowerCase: "lower", TitleCase: "title", UpperCase: "upper"}
+
+// String returns the name of the capitalization: lower, title or upper
+func (c Case) String() string {
+	if c < 0 || int(c) >= len(caseNames) {
+		return fmt.Sprintf("Case(%d)", int(c))
+	}
+	return caseNames[c]
+}
+
+// MarshalText writes the capitalization by its name, so it can be stored in JSON or YAML files
+func (c Case) MarshalText() ([]byte, error) {
+	if c < 0 || int(c) >= len(caseNames) {
+		return nil, fmt.Errorf("unknown case %d", int(c))
+	}
+	return []byte(caseNames[c]), nil
+}
+
+// UnmarshalText reads the capitalization from its name: lower, title or upper
+func (c *Case) UnmarshalText(text []byte) error {
+	for i, name := range caseNames {
+		if strings.EqualFold(string(text), name) {
+			*c = Case(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown case %q, it must be one of %s", text, strings.Join(caseNames, ", "))
+}
+
+// Style is how a word is written
+type Style struct {
+	Case Case
+	// SyllableSeparator is written between the syllables of the word, like "-" or "·"
+	SyllableSeparator string
+	// IdentifierSafe replaces every character of the syllables that is not an ASCII letter or digit, like the apostrophe
+	// some Aslan consonants are made of, with Replacement. The syllable separator is kept as it is.
+	IdentifierSafe bool
+	// Replacement is written instead of the characters removed by IdentifierSafe, nothing by default
+	Replacement string
+}
+
+// WithCase writes the generated words with the given capitalization
+func WithCase(c Case) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.style.Case = c
+	}
+}
+
+// WithSyllableSeparator writes the separator between the syllables of the generated words, like "hko-a-seas"
+func WithSyllableSeparator(separator string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.style.SyllableSeparator = separator
+	}
+}
+
+// WithIdentifierSafe replaces every character that is not an ASCII letter or digit, like the apostrophe some Aslan
+// consonants are made of, with the replacement. Use an empty replacement to remove them.
+func WithIdentifierSafe(replacement string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.style.IdentifierSafe = true
+		o.style.Replacement = replacement
+	}
+}
+
+// Styled returns the word written with the given style
+func (w Word) Styled(style Style) string {
+	syllables := w.Syllables()
+	if len(syllables) == 0 {
+		syllables = []string{w.text}
+	}
+	if style.IdentifierSafe {
+		for i, s := range syllables {
+			syllables[i] = identifierSafe(s, style.Replacement)
+		}
+	}
+	text := strings.Join(syllables, style.SyllableSeparator)
+	switch style.Case {
+	case TitleCase:
+		first, size := utf8.DecodeRuneInString(text)
+		return string(unicode.ToUpper(first)) + strings.ToLower(text[size:])
+	case UpperCase:
+		return strings.ToUpper(text)
+	default:
+		return strings.ToLower(text)
+	}
+}
+
+// Spelling returns the lowercase letters of a word written with any style, without its syllable separators or the
+// characters that are not letters, like apostrophes, so words written with different styles can be compared
+func Spelling(text string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, text)
+}
+
+func identifierSafe(text, replacement string) string {
+	var safe strings.Builder
+	for _, r := range text {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			safe.WriteRune(r)
+		} else {
+			safe.WriteString(replacement)
+		}
+	}
+	return safe.String()
+}
+
+// Format implements fmt.Formatter to write the word with these verbs:
+//   - %s and %v: the word as it is generated, "hkoaseas"
+//   - %q: the word double-quoted
+//   - %t: in title case, "Hkoaseas"
+//   - %S: in uppercase, "HKOASEAS"
+//   - %h: with its syllables separated by hyphens, "hko-a-seas"
+//   - %m: with its syllables separated by middle dots, "hko·a·seas"
+//   - %i: without the characters that are not ASCII letters or digits, like apostrophes
+//
+// Flags and width work as they do for strings.
+func (w Word) Format(f fmt.State, verb rune) {
+	var text string
+	switch verb {
+	case 's', 'v', 'q':
+		text = w.text
+	case 't':
+		text = w.Styled(Style{Case: TitleCase})
+	case 'S':
+		text = w.Styled(Style{Case: UpperCase})
+	case 'h':
+		text = w.Styled(Style{SyllableSeparator: "-"})
+	case 'm':
+		text = w.Styled(Style{SyllableSeparator: "·"})
+	case 'i':
+		text = w.Styled(Style{IdentifierSafe: true})
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(aslanwords.Word=%s)", verb, w.text)
+		return
+	}
+	if verb != 'q' {
+		verb = 's'
+	}
+	_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), text)
+}
+
+// weightedString returns the syllables of the word separated by hyphens followed by its weight
+func weightedString(w Word, weight float64) string {
+	return fmt.Sprintf("%h (%g)", w, weight)
+}
+
+// formatWeighted writes a word along with its weight with the verbs s, v and q, and only the word with the rest of verbs
+func formatWeighted(f fmt.State, verb rune, w Word, weight float64) {
+	switch verb {
+	case 's', 'v', 'q':
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), weightedString(w, weight))
+	default:
+		w.Format(f, verb)
+	}
+}
@@ -0,0 +1,123 @@
+package aslanwords_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWord_Styled(t *testing.T) {
+	word := segmentedWord(t, "kho'ao")
+
+	testCases := map[string]struct {
+		style        aslanwords.Style
+		expectedText string
+	}{
+		"default style":                  {aslanwords.Style{}, "kho'ao"},
+		"title case":                     {aslanwords.Style{Case: aslanwords.TitleCase}, "Kho'ao"},
+		"upper case":                     {aslanwords.Style{Case: aslanwords.UpperCase}, "KHO'AO"},
+		"syllable separator":             {aslanwords.Style{SyllableSeparator: "·"}, "kho'·ao"},
+		"identifier safe":                {aslanwords.Style{IdentifierSafe: true}, "khoao"},
+		"identifier safe with separator": {aslanwords.Style{IdentifierSafe: true, Replacement: "_", SyllableSeparator: "-"}, "kho_-ao"},
+		"everything":                     {aslanwords.Style{Case: aslanwords.TitleCase, SyllableSeparator: "-", IdentifierSafe: true}, "Kho-ao"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedText, word.Styled(tc.style))
+		})
+	}
+}
+
+func TestWord_Styled_with_syllable_separator_it_should_not_write_empty_syllables(t *testing.T) {
+	for seed := range uint64(2000) {
+		word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		styled := word.Styled(aslanwords.Style{SyllableSeparator: "-"})
+		assert.NotContains(t, styled, "--", "seed %d", seed)
+		assert.False(t, strings.HasPrefix(styled, "-") || strings.HasSuffix(styled, "-"), "seed %d: %s", seed, styled)
+	}
+}
+
+func TestSpelling_it_should_be_the_same_for_every_style_of_a_word(t *testing.T) {
+	word := segmentedWord(t, "kho'ao")
+	for _, style := range []aslanwords.Style{
+		{},
+		{Case: aslanwords.UpperCase, SyllableSeparator: "·"},
+		{Case: aslanwords.TitleCase, SyllableSeparator: "-", IdentifierSafe: true, Replacement: "_"},
+	} {
+		assert.Equal(t, "khoao", aslanwords.Spelling(word.Styled(style)), "style %+v", style)
+	}
+}
+
+func TestWord_Format(t *testing.T) {
+	word := segmentedWord(t, "kho'ao")
+
+	testCases := map[string]string{
+		"%s":   "kho'ao",
+		"%v":   "kho'ao",
+		"%q":   `"kho'ao"`,
+		"%t":   "Kho'ao",
+		"%S":   "KHO'AO",
+		"%h":   "kho'-ao",
+		"%m":   "kho'·ao",
+		"%i":   "khoao",
+		"%-8t": "Kho'ao  ",
+		"%8h":  " kho'-ao",
+		"%d":   "%!d(aslanwords.Word=kho'ao)",
+	}
+	for format, expectedText := range testCases {
+		t.Run(format, func(t *testing.T) {
+			assert.Equal(t, expectedText, fmt.Sprintf(format, word))
+		})
+	}
+}
+
+func TestGenerate_with_style_options_it_should_write_the_word_with_the_style(t *testing.T) {
+	ctx := context.Background()
+	style := aslanwords.Style{Case: aslanwords.UpperCase, SyllableSeparator: "-", IdentifierSafe: true, Replacement: "x"}
+
+	for seed := range uint64(20) {
+		word, err := aslanwords.GenerateWord(ctx, aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		styled, err := aslanwords.Generate(ctx, aslanwords.WithSeed(seed),
+			aslanwords.WithCase(aslanwords.UpperCase), aslanwords.WithSyllableSeparator("-"), aslanwords.WithIdentifierSafe("x"))
+		require.NoError(t, err)
+
+		assert.Equal(t, word.Styled(style), styled)
+	}
+}
+
+func TestGenerateN_with_style_options_it_should_write_the_words_with_the_style(t *testing.T) {
+	words, err := aslanwords.GenerateN(context.Background(), 20, aslanwords.WithCase(aslanwords.TitleCase))
+	require.NoError(t, err)
+
+	for _, word := range words {
+		assert.Regexp(t, "^[A-Z][^A-Z]*$", word)
+	}
+}
+
+func segmentedWord(t *testing.T, text string) aslanwords.Word {
+	t.Helper()
+	segmentations, err := aslanwords.Segment(text)
+	require.NoError(t, err)
+	return segmentations[0].Word
+}
+
+func TestCase_it_should_be_written_and_read_by_its_name(t *testing.T) {
+	for _, c := range []aslanwords.Case{aslanwords.LowerCase, aslanwords.TitleCase, aslanwords.UpperCase} {
+		text, err := c.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, c.String(), string(text))
+
+		var read aslanwords.Case
+		require.NoError(t, read.UnmarshalText([]byte(strings.ToUpper(string(text)))))
+		assert.Equal(t, c, read)
+	}
+}
@@ -0,0 +1,174 @@
+package aslanwords
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// FragmentFits tells whether any word can have the lowercase fragment.
+// When atStart the fragment has to be at the start of the word and when atEnd it has to be at its end,
+// otherwise the fragment may start or end in the middle of a syllable.
+func (p *phonotactics) FragmentFits(fragment string, atStart, atEnd bool) bool {
+	if fragment == "" {
+		return true
+	}
+	type state struct {
+		position int
+		key      string
+		vowel    string
+	}
+	deadEnds := make(map[state]bool)
+	var fits func(position int, previous parsedSyllable) bool
+	fits = func(position int, previous parsedSyllable) bool {
+		if position == len(fragment) {
+			return true
+		}
+		current := state{position: position, key: previous.shape.Key, vowel: previous.vowel()}
+		if deadEnds[current] {
+			return false
+		}
+		for _, shape := range p.shapes {
+			if !p.rules.CanBeFollowedBy(previous.shape.Key, shape.Key) {
+				continue
+			}
+			for _, next := range p.fragmentSyllables(fragment, position, shape, 0, false, !atEnd) {
+				if p.repeatsSingleVowel(previous, next.parsedSyllable) {
+					continue
+				}
+				if next.unfinished || fits(position+next.length(), next.parsedSyllable) {
+					return true
+				}
+			}
+		}
+		deadEnds[current] = true
+		return false
+	}
+
+	for _, shape := range p.shapes {
+		for firstSlot := range shape.Slots {
+			if atStart && firstSlot > 0 {
+				break
+			}
+			for _, first := range p.fragmentSyllables(fragment, 0, shape, firstSlot, !atStart, !atEnd) {
+				if first.unfinished || fits(first.length(), first.parsedSyllable) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// fragmentSplits returns the ways the lowercase fragment can be split into at most maxSyllables syllables, as FragmentFits
+// finds them, sorted by their number of syllables. When atStart the fragment is the start of a word and its last syllable
+// can go on after it, otherwise it is the end of a word and its first syllable can start before it.
+func (p *phonotactics) fragmentSplits(fragment string, atStart bool, maxSyllables int) [][]fragmentSyllable {
+	if fragment == "" {
+		return nil
+	}
+	var splits [][]fragmentSyllable
+	var split func(position int, syllables []fragmentSyllable)
+	split = func(position int, syllables []fragmentSyllable) {
+		previous := syllables[len(syllables)-1]
+		if previous.unfinished || position == len(fragment) {
+			splits = append(splits, slices.Clone(syllables))
+			return
+		}
+		if len(syllables) == maxSyllables {
+			return
+		}
+		for _, shape := range p.shapes {
+			if shape.Weight == 0 || !p.rules.CanBeFollowedBy(previous.shape.Key, shape.Key) {
+				continue
+			}
+			for _, next := range p.fragmentSyllables(fragment, position, shape, 0, false, atStart) {
+				if next.length() > 0 && !p.repeatsSingleVowel(previous.parsedSyllable, next.parsedSyllable) {
+					split(position+next.length(), append(syllables, next))
+				}
+			}
+		}
+	}
+
+	for _, shape := range p.shapes {
+		if shape.Weight == 0 || maxSyllables < 1 {
+			continue
+		}
+		for firstSlot := range shape.Slots {
+			if atStart && firstSlot > 0 {
+				break
+			}
+			for _, first := range p.fragmentSyllables(fragment, 0, shape, firstSlot, !atStart, atStart) {
+				split(first.length(), []fragmentSyllable{first})
+			}
+		}
+	}
+	slices.SortStableFunc(splits, func(a, b []fragmentSyllable) int {
+		return len(a) - len(b)
+	})
+	return splits
+}
+
+// fragmentSyllable is a syllable found in a fragment of a word that may not be finished when the fragment ends
+type fragmentSyllable struct {
+	parsedSyllable
+	unfinished bool
+}
+
+// fragmentSyllables returns the syllables of the given shape the fragment can have at the position starting at the given slot.
+// When startInTheMiddle the first slot may be entered in the middle of its letters, and when canBeUnfinished the fragment
+// can end before the syllable does.
+func (p *phonotactics) fragmentSyllables(fragment string, position int, shape syllable.Shape, firstSlot int, startInTheMiddle, canBeUnfinished bool) []fragmentSyllable {
+	start := parsedSyllable{
+		shape:   shape,
+		options: make([]string, firstSlot),
+		written: make([]string, firstSlot),
+	}
+	var found []fragmentSyllable
+	var walk func(slot, position int, partial parsedSyllable)
+	walk = func(slot, position int, partial parsedSyllable) {
+		if slot == len(shape.Slots) {
+			found = append(found, fragmentSyllable{parsedSyllable: partial})
+			return
+		}
+		if position == len(fragment) {
+			if canBeUnfinished {
+				found = append(found, fragmentSyllable{parsedSyllable: partial, unfinished: true})
+			}
+			return
+		}
+		for option := range p.slotOptions(shape.Slots[slot]) {
+			for _, written := range writtenForms(fragment, position, option, startInTheMiddle && slot == firstSlot) {
+				next := parsedSyllable{
+					shape:   shape,
+					options: append(slices.Clone(partial.options), option),
+					written: append(slices.Clone(partial.written), written),
+				}
+				rest := fragment[position:]
+				switch {
+				case strings.HasPrefix(rest, written):
+					walk(slot+1, position+len(written), next)
+				case canBeUnfinished && strings.HasPrefix(written, rest):
+					next.written[len(next.written)-1] = rest
+					found = append(found, fragmentSyllable{parsedSyllable: next, unfinished: true})
+				}
+			}
+		}
+	}
+	walk(firstSlot, position, start)
+	return found
+}
+
+// writtenForms returns the ways the option can be written at the position of the fragment.
+// When inTheMiddle the fragment may start with any ending of the option.
+func writtenForms(fragment string, position int, option string, inTheMiddle bool) []string {
+	if inTheMiddle {
+		forms := make([]string, len(option))
+		for i := range option {
+			forms[i] = option[i:]
+		}
+		return forms
+	}
+	return []string{collapsedOption(fragment, position, option)}
+}
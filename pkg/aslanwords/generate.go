@@ -3,31 +3,27 @@ package aslanwords
 
 import (
 	"context"
-	"fmt"
-	"math/rand"
-
-	"github.com/carloscasalar/aslan-words/internal/syllable"
-	"github.com/s0rg/fantasyname"
 )
 
 // Generate generates a random Aslan word with the given options.
 // If no options are provided, it will generate-word a word with a random number of syllables between 2 and 6.
 func Generate(ctx context.Context, opts ...GeneratorOption) (string, error) {
-	options := newGeneratorOptions()
-	for _, o := range opts {
-		o(options)
-	}
-	err := options.Validate()
+	gen, err := New(opts...)
 	if err != nil {
-		return "", fmt.Errorf("invalid options: %w", err)
+		return "", err
 	}
+	return gen.Generate(ctx)
+}
 
-	wordTemplate := syllable.GenerateTemplate(options.numberOfSyllables())
-	gen, err := fantasyname.Compile(wordTemplate.String(), fantasyname.Collapse(true), fantasyname.RandFn(rand.Intn))
+// GenerateWord generates a random Aslan word with the given options along with the syllables it is made of.
+// If no options are provided, it will generate-word a word with a random number of syllables between 2 and 6.
+// The word may have fewer syllables than generated, see WithNumberOfSyllables.
+func GenerateWord(ctx context.Context, opts ...GeneratorOption) (Word, error) {
+	gen, err := New(opts...)
 	if err != nil {
-		return "", fmt.Errorf("unexpected error generating the aslan word: %w", err)
+		return Word{}, err
 	}
-	return gen.String(), nil
+	return gen.GenerateWord(ctx)
 }
 
 // MustGenerate generates a random Aslan word with the given options.
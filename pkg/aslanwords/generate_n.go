@@ -0,0 +1,70 @@
+package aslanwords
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrExhausted is returned when the requested amount of different words cannot be generated,
+// either because the options do not allow that many words or because the retry budget ran out
+var ErrExhausted = errors.New("unable to generate enough different aslan words")
+
+// GenerateN generates n different random Aslan words with the given options.
+// Use WithMaxAttemptsPerWord to tune how many repeated words are tolerated before giving up with ErrExhausted.
+func GenerateN(ctx context.Context, n int, opts ...GeneratorOption) ([]string, error) {
+	gen, err := New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gen.GenerateN(ctx, n)
+}
+
+// GenerateN generates n different random Aslan words, which are still different once written with the style of the options.
+// Words too close to the ones already generated, see WithMinEditDistance and WithMaxSharedPrefix, are discarded as repeated ones.
+// It returns ErrExhausted when the generator produces only already generated words for more attempts than allowed
+// by WithMaxAttemptsPerWord, and the context error if the context is done before all the words are generated.
+func (g *Generator) GenerateN(ctx context.Context, n int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("number of words cannot be negative")
+	}
+
+	words := make([]string, 0, n)
+	generated := make(map[string]struct{}, n)
+	generatedSounds := make([][]string, 0, n)
+	failedAttempts := 0
+	for len(words) < n {
+		word, err := g.GenerateWord(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// words that only differ in the characters the style removes, like the apostrophe of identifier safe words, are repeated
+		styled := g.Styled(word)
+		sounds := word.sounds()
+		if _, alreadyGenerated := generated[styled]; alreadyGenerated || !g.isDistinct(sounds, generatedSounds) {
+			failedAttempts++
+			if failedAttempts >= g.options.maxAttemptsPerWord {
+				return nil, fmt.Errorf("%w: got %d of %d after %d attempts without a new word", ErrExhausted, len(words), n, failedAttempts)
+			}
+			continue
+		}
+		failedAttempts = 0
+		generated[styled] = struct{}{}
+		generatedSounds = append(generatedSounds, sounds)
+		words = append(words, styled)
+	}
+	return words, nil
+}
+
+// isDistinct tells whether a word with the given consonants and vowels is far enough from every generated one
+func (g *Generator) isDistinct(sounds []string, generatedSounds [][]string) bool {
+	for _, generated := range generatedSounds {
+		if sharedPrefix(sounds, generated) > g.options.maxSharedPrefix {
+			return false
+		}
+		if g.options.minEditDistance > 1 && editDistance(sounds, generated) < g.options.minEditDistance {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package aslanwords_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateN_should_generate_the_requested_number_of_different_words(t *testing.T) {
+	ctx := context.Background()
+	words, err := aslanwords.GenerateN(ctx, 200, aslanwords.WithNumberOfSyllablesBetween(2, 4))
+	require.NoError(t, err)
+
+	require.Len(t, words, 200)
+	assert.Len(t, uniqueWords(words), 200)
+}
+
+func TestGenerateN_with_identifier_safe_style_words_should_be_different_once_styled(t *testing.T) {
+	words, err := aslanwords.GenerateN(context.Background(), 1500,
+		aslanwords.WithNumberOfSyllables(1),
+		aslanwords.WithIdentifierSafe(""),
+		aslanwords.WithSeed(1),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, words, 1500)
+	assert.Len(t, uniqueWords(words), 1500)
+}
+
+func TestGenerateN_when_zero_words_are_requested_it_should_return_no_words(t *testing.T) {
+	words, err := aslanwords.GenerateN(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, words)
+}
+
+func TestGenerateN_when_a_negative_number_of_words_is_requested_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.GenerateN(context.Background(), -1)
+	assert.Error(t, err)
+}
+
+func TestGenerateN_when_options_cannot_produce_enough_words_it_should_return_exhausted_error(t *testing.T) {
+	_, err := aslanwords.GenerateN(context.Background(), 100_000,
+		aslanwords.WithNumberOfSyllables(1),
+		aslanwords.WithMaxAttemptsPerWord(50),
+	)
+	assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+}
+
+func TestGenerateN_when_context_is_cancelled_it_should_return_the_context_error(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := aslanwords.GenerateN(ctx, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGenerateN_when_max_attempts_per_word_is_not_positive_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.GenerateN(context.Background(), 10, aslanwords.WithMaxAttemptsPerWord(0))
+	assert.Error(t, err)
+}
+
+func TestGenerateN_with_max_shared_prefix_words_should_not_share_more_consonants_or_vowels(t *testing.T) {
+	ctx := context.Background()
+	opts := []aslanwords.GeneratorOption{
+		aslanwords.WithPhonology(consonantVowelPhonology), aslanwords.WithNumberOfSyllables(2), aslanwords.WithMaxSharedPrefix(0),
+	}
+
+	words, err := aslanwords.GenerateN(ctx, 3, opts...)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []byte{'k', 's', 't'}, []byte{words[0][0], words[1][0], words[2][0]})
+
+	_, err = aslanwords.GenerateN(ctx, 4, opts...)
+	assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+}
+
+func TestGenerateN_with_min_edit_distance_words_should_differ_in_enough_consonants_or_vowels(t *testing.T) {
+	ctx := context.Background()
+	opts := []aslanwords.GeneratorOption{
+		aslanwords.WithPhonology(consonantVowelPhonology), aslanwords.WithNumberOfSyllables(2), aslanwords.WithMinEditDistance(4),
+	}
+
+	// words like "kate" have four sounds, so they must differ in all of them
+	words, err := aslanwords.GenerateN(ctx, 3, opts...)
+	require.NoError(t, err)
+	for i := range words {
+		for j := range i {
+			for position := range len(words[i]) {
+				assert.NotEqual(t, words[i][position], words[j][position], "%s and %s", words[i], words[j])
+			}
+		}
+	}
+
+	_, err = aslanwords.GenerateN(ctx, 4, opts...)
+	assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+}
+
+func TestGenerateN_edit_distance_should_count_consonants_and_vowels_instead_of_letters(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "hk", Weight: 1}, {Text: "kh", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+
+	// hka and kha are two letters away but only one consonant
+	_, err := aslanwords.GenerateN(context.Background(), 2,
+		aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllables(1), aslanwords.WithMinEditDistance(2))
+
+	assert.ErrorIs(t, err, aslanwords.ErrExhausted)
+}
+
+func TestGenerateN_when_distance_options_are_negative_it_should_return_error(t *testing.T) {
+	testCases := map[string]struct {
+		option        aslanwords.GeneratorOption
+		expectedError string
+	}{
+		"min edit distance": {aslanwords.WithMinEditDistance(-1), "min edit distance cannot be negative"},
+		"max shared prefix": {aslanwords.WithMaxSharedPrefix(-1), "max shared prefix cannot be negative"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.GenerateN(context.Background(), 2, tc.option)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+var consonantVowelPhonology = aslanwords.Phonology{
+	FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}, {Text: "s", Weight: 1}, {Text: "t", Weight: 1}},
+	Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}, {Text: "e", Weight: 1}, {Text: "o", Weight: 1}},
+	LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+	SyllableWeights: map[string]int{"CV": 1},
+}
+
+func uniqueWords(words []string) map[string]struct{} {
+	unique := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		unique[word] = struct{}{}
+	}
+	return unique
+}
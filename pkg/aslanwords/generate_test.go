@@ -2,6 +2,7 @@ package aslanwords_test
 
 import (
 	"context"
+	"math/rand/v2"
 	"testing"
 
 	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
@@ -41,3 +42,54 @@ func TestGenerate_when_invalid_range_of_syllables_generate_should_return_error(t
 	_, err := aslanwords.Generate(ctx, aslanwords.WithNumberOfSyllablesBetween(5, 3))
 	assert.Error(t, err)
 }
+
+func TestGenerate_when_called_with_the_same_seed_it_should_always_generate_the_same_word(t *testing.T) {
+	ctx := context.Background()
+	for seed := range uint64(50) {
+		first, err := aslanwords.Generate(ctx, aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		second, err := aslanwords.Generate(ctx, aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second, "seed %d generated different words", seed)
+	}
+}
+
+func TestGenerate_when_called_with_different_seeds_it_should_generate_different_words(t *testing.T) {
+	ctx := context.Background()
+	words := make(map[string]struct{})
+	for seed := range uint64(50) {
+		word, err := aslanwords.Generate(ctx, aslanwords.WithSeed(seed), aslanwords.WithNumberOfSyllables(4))
+		require.NoError(t, err)
+		words[word] = struct{}{}
+	}
+
+	assert.Greater(t, len(words), 1)
+}
+
+func TestGenerate_when_called_with_equivalent_rand_sources_it_should_generate_the_same_word(t *testing.T) {
+	ctx := context.Background()
+	first, err := aslanwords.Generate(ctx, aslanwords.WithRandSource(rand.NewPCG(1, 2)))
+	require.NoError(t, err)
+
+	second, err := aslanwords.Generate(ctx, aslanwords.WithRandSource(rand.NewPCG(1, 2)))
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestGenerate_when_range_of_syllables_has_a_single_value_it_should_not_return_error(t *testing.T) {
+	ctx := context.Background()
+	word, err := aslanwords.Generate(ctx, aslanwords.WithNumberOfSyllablesBetween(3, 3))
+	require.NoError(t, err)
+	assert.NotEmpty(t, word)
+}
+
+func TestGenerate_when_context_is_cancelled_it_should_return_the_context_error(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := aslanwords.Generate(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,190 @@
+package aslanwords
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+	"github.com/s0rg/fantasyname"
+	"github.com/s0rg/fantasyname/stringers"
+	"github.com/s0rg/fantasyname/wrappers"
+)
+
+// WordGenerator is anything able to generate Aslan words. Depend on it instead of *Generator to mock the generation
+type WordGenerator interface {
+	Generate(ctx context.Context) (string, error)
+}
+
+var _ WordGenerator = (*Generator)(nil)
+
+// Generator generates random Aslan words.
+// The options are validated once on creation and the generators of the syllable parts are compiled once and reused,
+// so a Generator is meant to be created once and used many times. It is safe for concurrent use.
+type Generator struct {
+	options           *GeneratorOptions
+	randomIntegerUpTo func(int) int
+	affixes           affixSplits
+	slotGenerators    sync.Map
+	rejections        atomic.Int64
+}
+
+// New creates a Generator with the given options.
+// If no options are provided, it will generate-word words with a random number of syllables between 2 and 6.
+func New(opts ...GeneratorOption) (*Generator, error) {
+	options := newGeneratorOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	return newGenerator(options)
+}
+
+// newGenerator creates a Generator with the options once they have all been applied
+func newGenerator(options *GeneratorOptions) (*Generator, error) {
+	if err := options.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %w", err)
+	}
+
+	return &Generator{
+		options:           options,
+		randomIntegerUpTo: newConcurrentSafeRandom(options.source()),
+		affixes:           options.constraints.Splits(options.phonotactics, options.maxNumberOfSyllables()),
+	}, nil
+}
+
+// Generate generates a random Aslan word written with the style of the options
+func (g *Generator) Generate(ctx context.Context) (string, error) {
+	word, err := g.GenerateWord(ctx)
+	if err != nil {
+		return "", err
+	}
+	return g.Styled(word), nil
+}
+
+// Styled returns the word written with the style of the options, see WithCase, WithSyllableSeparator and WithIdentifierSafe
+func (g *Generator) Styled(word Word) string {
+	if g.options.style == (Style{}) {
+		return word.String()
+	}
+	return word.Styled(g.options.style)
+}
+
+// GenerateWord generates a random Aslan word along with its syllables.
+// Syllables whose letters are all collapsed with the previous one are merged, so the word may have fewer syllables than generated.
+// The word is not written with the style of the options, use Word.Styled or the verbs of its Format method for that.
+// Words that do not meet the constraints are discarded, giving up with an *UnmetConstraintError, that matches ErrExhausted,
+// when too many are discarded in a row.
+func (g *Generator) GenerateWord(ctx context.Context) (Word, error) {
+	unmet := make(map[string]int)
+	for range g.options.maxAttemptsPerWord {
+		word, err := g.generateWord(ctx)
+		if err != nil {
+			return Word{}, err
+		}
+		if g.options.validWordsOnly && len(word.syllables) == 0 {
+			unmet["valid words only"]++
+			continue
+		}
+		if g.options.contentFilter.Rejects(word.String()) {
+			g.rejections.Add(1)
+			unmet["content filter"]++
+			continue
+		}
+		constraint := g.options.constraints.Unmet(word.String())
+		if constraint == "" {
+			return word, nil
+		}
+		unmet[constraint]++
+	}
+	return Word{}, &UnmetConstraintError{Constraint: mostUnmet(unmet), Attempts: g.options.maxAttemptsPerWord}
+}
+
+// mostUnmet returns the constraint with the most discarded words, the first one in alphabetical order on ties
+func mostUnmet(unmet map[string]int) string {
+	constraints := slices.Sorted(maps.Keys(unmet))
+	most := ""
+	for _, constraint := range constraints {
+		if most == "" || unmet[constraint] > unmet[most] {
+			most = constraint
+		}
+	}
+	return most
+}
+
+// Rejections returns how many generated words have been discarded so far by the content filter, see WithContentFilter
+func (g *Generator) Rejections() int64 {
+	return g.rejections.Load()
+}
+
+func (g *Generator) generateWord(ctx context.Context) (Word, error) {
+	if err := ctx.Err(); err != nil {
+		return Word{}, err
+	}
+	if g.options.markov != nil {
+		return g.options.phonotactics.Split(g.options.markov.Generate(g.randomIntegerUpTo)), nil
+	}
+	numberOfSyllables := g.options.numberOfSyllables(g.randomIntegerUpTo)
+	templateOptions := append([]syllable.TemplateOption{
+		syllable.WithSyllableChanceGenerator(g.randomIntegerUpTo),
+		syllable.WithVowelTemplateChanceGenerator(g.randomIntegerUpTo),
+		syllable.WithRules(g.options.phonotactics.rules),
+	}, g.affixes.TemplateOptions(numberOfSyllables, g.randomIntegerUpTo)...)
+	wordTemplate := syllable.GenerateTemplate(numberOfSyllables, templateOptions...)
+
+	keys := wordTemplate.SyllableKeySequence()
+	builder := newWordBuilder()
+	for i, slots := range wordTemplate.SlotSequence() {
+		builder.StartSyllable(keys[i])
+		for _, slot := range slots {
+			gen, err := g.slotGenerator(slot.Template)
+			if err != nil {
+				return Word{}, fmt.Errorf("unexpected error generating the aslan word: %w", err)
+			}
+			builder.AddSlot(slot.Kind, gen.String())
+		}
+	}
+	return builder.Word(), nil
+}
+
+// slotGenerator returns the compiled generator of the given slot template, compiling it on its first use
+func (g *Generator) slotGenerator(slotTemplate string) (fmt.Stringer, error) {
+	if gen, ok := g.slotGenerators.Load(slotTemplate); ok {
+		return gen.(fmt.Stringer), nil
+	}
+	gen, err := fantasyname.Compile(slotTemplate, fantasyname.RandFn(g.randomIntegerUpTo))
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := g.slotGenerators.LoadOrStore(slotTemplate, gen)
+	return actual.(fmt.Stringer), nil
+}
+
+// collapse removes the letters repeated by the concatenation of syllables the same way fantasyname does
+func collapse(word string) string {
+	return wrappers.Collapsed(stringers.Literal(word)).String()
+}
+
+// newConcurrentSafeRandom returns a random function that can be shared by many goroutines.
+// The global generator of math/rand/v2 already is, a custom source needs to be guarded.
+func newConcurrentSafeRandom(src rand.Source) func(int) int {
+	if src == nil {
+		return rand.IntN
+	}
+	r := &lockedRandom{random: rand.New(src)}
+	return r.IntN
+}
+
+type lockedRandom struct {
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+func (r *lockedRandom) IntN(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.random.IntN(n)
+}
@@ -0,0 +1,98 @@
+package aslanwords_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_when_options_are_invalid_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.New(aslanwords.WithNumberOfSyllablesBetween(5, 3))
+	assert.Error(t, err)
+}
+
+func TestGenerator_when_created_with_the_same_seed_it_should_generate_the_same_sequence_of_words(t *testing.T) {
+	first, err := aslanwords.New(aslanwords.WithSeed(7))
+	require.NoError(t, err)
+	second, err := aslanwords.New(aslanwords.WithSeed(7))
+	require.NoError(t, err)
+
+	for range 20 {
+		assert.Equal(t, mustGenerate(t, first), mustGenerate(t, second))
+	}
+}
+
+func TestGenerator_when_the_same_seed_option_is_reused_it_should_generate_the_same_sequence_of_words(t *testing.T) {
+	seed := aslanwords.WithSeed(42)
+	first, err := aslanwords.New(seed)
+	require.NoError(t, err)
+	second, err := aslanwords.New(seed)
+	require.NoError(t, err)
+
+	for range 20 {
+		assert.Equal(t, mustGenerate(t, first), mustGenerate(t, second))
+	}
+}
+
+func TestGenerator_when_generators_sharing_a_seed_option_are_used_at_the_same_time_it_should_generate_words(t *testing.T) {
+	seed := aslanwords.WithSeed(42)
+	var wg sync.WaitGroup
+	words := make([]string, 8)
+	for i := range words {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gen, err := aslanwords.New(seed)
+			if err == nil {
+				words[i], _ = gen.Generate(context.Background())
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, word := range words {
+		assert.Equal(t, words[0], word)
+	}
+}
+
+func TestGenerator_first_word_should_be_the_one_generated_by_the_package_function_with_the_same_options(t *testing.T) {
+	ctx := context.Background()
+	gen, err := aslanwords.New(aslanwords.WithSeed(3), aslanwords.WithNumberOfSyllables(4))
+	require.NoError(t, err)
+
+	expectedWord := aslanwords.MustGenerate(ctx, aslanwords.WithSeed(3), aslanwords.WithNumberOfSyllables(4))
+
+	assert.Equal(t, expectedWord, mustGenerate(t, gen))
+}
+
+func TestGenerator_when_used_from_many_goroutines_it_should_generate_words(t *testing.T) {
+	ctx := context.Background()
+	gen, err := aslanwords.New(aslanwords.WithSeed(11))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	words := make([]string, 100)
+	for i := range words {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			words[i], _ = gen.Generate(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for _, word := range words {
+		assert.NotEmpty(t, word)
+	}
+}
+
+func mustGenerate(t *testing.T, gen aslanwords.WordGenerator) string {
+	t.Helper()
+	word, err := gen.Generate(context.Background())
+	require.NoError(t, err)
+	return word
+}
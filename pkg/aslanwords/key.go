@@ -0,0 +1,44 @@
+package aslanwords
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+// WithNamespace salts the keys given to ForKey, so the same key gets different words in different namespaces,
+// like the NPCs of two campaigns. It has no effect on the other functions.
+func WithNamespace(namespace string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.namespace = namespace
+	}
+}
+
+// ForKey returns the word of the key, like the ID of a character, so there is no need to store it:
+// the same key with the same options always gets the same word in every release of the same major version of the library.
+// The random numbers are taken from a hash of the key and the namespace, see WithNamespace, so the seed and rand source
+// of the options are ignored.
+func ForKey(key string, opts ...GeneratorOption) (string, error) {
+	options := newGeneratorOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	WithRandSource(keySource(options.namespace, key))(options)
+	gen, err := newGenerator(options)
+	if err != nil {
+		return "", err
+	}
+	return gen.Generate(context.Background())
+}
+
+// keySource returns a source of random numbers seeded with the SHA-256 hash of the namespace and the key.
+// Changing how it is seeded changes the words of every key, so it can only be done in a new major version.
+func keySource(namespace, key string) rand.Source {
+	hash := sha256.New()
+	_ = binary.Write(hash, binary.BigEndian, uint64(len(namespace)))
+	hash.Write([]byte(namespace))
+	hash.Write([]byte(key))
+	sum := hash.Sum(nil)
+	return rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16]))
+}
@@ -0,0 +1,100 @@
+package aslanwords_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files. The words of ForKey are part of the public contract,
+// so the golden file of ForKey can only change in a new major version.
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+const forKeyGoldenFile = "testdata/for_key.golden"
+
+var forKeyGoldenCases = []struct {
+	name string
+	opts []aslanwords.GeneratorOption
+}{
+	{"default", nil},
+	{"namespace", []aslanwords.GeneratorOption{aslanwords.WithNamespace("drinax")}},
+	{"three syllables", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(3)}},
+	{"vargr", []aslanwords.GeneratorOption{aslanwords.WithProfile("vargr")}},
+	{"ship", []aslanwords.GeneratorOption{aslanwords.WithCategory(aslanwords.Ship)}},
+}
+
+var forKeyGoldenKeys = []string{"", "npc-0001", "npc-0002", "npc-0003", "npc-0010", "npc-0100", "Hkoaseas", "42", "ñandú", "7f3c2a9e-5b1d-4c8e-9a61-0d2b7e4f8c13"}
+
+func TestForKey_should_generate_the_words_of_the_golden_file(t *testing.T) {
+	var golden strings.Builder
+	for _, tc := range forKeyGoldenCases {
+		for _, key := range forKeyGoldenKeys {
+			word, err := aslanwords.ForKey(key, tc.opts...)
+			require.NoError(t, err)
+			_, _ = fmt.Fprintf(&golden, "%s\t%q\t%s\n", tc.name, key, word)
+		}
+	}
+
+	if *update {
+		require.NoError(t, os.WriteFile(forKeyGoldenFile, []byte(golden.String()), 0o644))
+	}
+	expected, err := os.ReadFile(forKeyGoldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), golden.String(), "the words of the keys have changed, which breaks the compatibility of the major version")
+}
+
+func TestForKey_when_called_with_the_same_key_it_should_always_generate_the_same_word(t *testing.T) {
+	expectedWord, err := aslanwords.ForKey("npc-0001")
+	require.NoError(t, err)
+
+	for range 10 {
+		word, err := aslanwords.ForKey("npc-0001")
+		require.NoError(t, err)
+		assert.Equal(t, expectedWord, word)
+	}
+}
+
+func TestForKey_it_should_ignore_the_seed_of_the_options(t *testing.T) {
+	expectedWord, err := aslanwords.ForKey("npc-0001")
+	require.NoError(t, err)
+
+	word, err := aslanwords.ForKey("npc-0001", aslanwords.WithSeed(42))
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedWord, word)
+}
+
+func TestForKey_when_called_with_different_keys_or_namespaces_it_should_generate_different_words(t *testing.T) {
+	words := make(map[string]bool)
+	for i := range 50 {
+		for _, namespace := range []string{"", "drinax", "drina", "x"} {
+			word, err := aslanwords.ForKey(fmt.Sprintf("npc-%d", i), aslanwords.WithNamespace(namespace), aslanwords.WithNumberOfSyllables(4))
+			require.NoError(t, err)
+			words[word] = true
+		}
+	}
+
+	assert.Greater(t, len(words), 190)
+}
+
+func TestForKey_when_the_namespace_is_part_of_the_key_it_should_not_get_the_same_word(t *testing.T) {
+	word, err := aslanwords.ForKey("ax", aslanwords.WithNamespace("drin"), aslanwords.WithNumberOfSyllables(5))
+	require.NoError(t, err)
+
+	other, err := aslanwords.ForKey("x", aslanwords.WithNamespace("drina"), aslanwords.WithNumberOfSyllables(5))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, word, other)
+}
+
+func TestForKey_when_options_are_invalid_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.ForKey("npc-0001", aslanwords.WithNumberOfSyllables(0))
+
+	assert.ErrorContains(t, err, "invalid options")
+}
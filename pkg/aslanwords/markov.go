@@ -0,0 +1,187 @@
+package aslanwords
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMarkovLetters is the length a word generated by a Markov model is cut at, in case the model keeps going round in circles
+const maxMarkovLetters = 64
+
+// MarkovModel is the chance of each letter of a word given the letters before it, learnt from a corpus of words.
+// It can be saved to a JSON file and loaded back to generate words with WithMarkov.
+type MarkovModel struct {
+	// Order is the number of letters before a letter taken into account to pick it
+	Order int `json:"order"`
+	// Transitions counts, for the last Order letters of the beginning of a word, or fewer at its start, the times
+	// each letter follows them in the corpus. The empty letter is the end of the word.
+	Transitions map[string]map[string]int `json:"transitions"`
+}
+
+// TrainMarkov learns a Markov model of the given order from the words of the corpus. Words are lowercased and
+// the empty ones are skipped. The higher the order the more the generated words resemble the ones in the corpus.
+func TrainMarkov(corpus []string, order int) (*MarkovModel, error) {
+	if order < 1 {
+		return nil, fmt.Errorf("order must be one or greater")
+	}
+	model := &MarkovModel{Order: order, Transitions: make(map[string]map[string]int)}
+	for _, word := range corpus {
+		letters := strings.Split(strings.ToLower(strings.TrimSpace(word)), "")
+		if len(letters) == 0 || letters[0] == "" {
+			continue
+		}
+		for i := range len(letters) + 1 {
+			context := strings.Join(letters[max(0, i-order):i], "")
+			next := ""
+			if i < len(letters) {
+				next = letters[i]
+			}
+			if model.Transitions[context] == nil {
+				model.Transitions[context] = make(map[string]int)
+			}
+			model.Transitions[context][next]++
+		}
+	}
+	if len(model.Transitions) == 0 {
+		return nil, fmt.Errorf("corpus must have at least one word")
+	}
+	return model, nil
+}
+
+// Validate checks the model can generate words
+func (m *MarkovModel) Validate() error {
+	if m.Order < 1 {
+		return fmt.Errorf("order must be one or greater")
+	}
+	if len(m.Transitions[""]) == 0 {
+		return fmt.Errorf("the model has no letter to start a word with")
+	}
+	for context, letters := range m.Transitions {
+		if utf8.RuneCountInString(context) > m.Order {
+			return fmt.Errorf("letters %q are more than the order of the model", context)
+		}
+		if len(letters) == 0 {
+			return fmt.Errorf("letters %q must be followed by some letter or the end of the word", context)
+		}
+		for letter, count := range letters {
+			if utf8.RuneCountInString(letter) > 1 {
+				return fmt.Errorf("%q following %q is not a single letter", letter, context)
+			}
+			if count < 1 {
+				return fmt.Errorf("%q following %q must have a count of one or greater", letter, context)
+			}
+		}
+	}
+	return nil
+}
+
+// Save writes the model to a JSON file
+func (m *MarkovModel) Save(path string) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("unable to encode the markov model: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("unable to write the markov model: %w", err)
+	}
+	return nil
+}
+
+// LoadMarkovModel reads a model saved with MarkovModel.Save and checks it is valid
+func LoadMarkovModel(path string) (*MarkovModel, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the markov model: %w", err)
+	}
+	var model MarkovModel
+	if err := json.Unmarshal(content, &model); err != nil {
+		return nil, fmt.Errorf("unable to parse the markov model: %w", err)
+	}
+	if err := model.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid markov model: %w", err)
+	}
+	return &model, nil
+}
+
+// WithMarkov generates words letter by letter with the model instead of joining syllables.
+// The number of syllables is ignored. Generated words may break the rules of the language unless WithValidWordsOnly is used,
+// and the ones that do have no syllables.
+func WithMarkov(model *MarkovModel) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		if model == nil {
+			o.markovErr = fmt.Errorf("markov model cannot be nil")
+			return
+		}
+		if err := model.Validate(); err != nil {
+			o.markovErr = fmt.Errorf("invalid markov model: %w", err)
+			return
+		}
+		o.markovErr = nil
+		o.markov = newMarkovChain(model)
+	}
+}
+
+// WithValidWordsOnly discards the generated words that break the rules of the language, see Validate.
+// Words generated from syllables always follow them, so it is only needed along with WithMarkov.
+func WithValidWordsOnly() GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.validWordsOnly = true
+	}
+}
+
+// markovChain is a MarkovModel ready to pick letters: the letters following each context are sorted so the same
+// random numbers always pick the same letters
+type markovChain struct {
+	order int
+	next  map[string]markovOptions
+}
+
+type markovOptions struct {
+	letters []string
+	counts  []int
+	total   int
+}
+
+func newMarkovChain(model *MarkovModel) *markovChain {
+	chain := &markovChain{order: model.Order, next: make(map[string]markovOptions, len(model.Transitions))}
+	for context, letters := range model.Transitions {
+		var options markovOptions
+		for _, letter := range slices.Sorted(maps.Keys(letters)) {
+			options.letters = append(options.letters, letter)
+			options.counts = append(options.counts, letters[letter])
+			options.total += letters[letter]
+		}
+		chain.next[context] = options
+	}
+	return chain
+}
+
+// Generate returns a lowercase word picking each letter with the random function
+func (c *markovChain) Generate(randomIntegerUpTo func(int) int) string {
+	var letters []string
+	for len(letters) < maxMarkovLetters {
+		options, ok := c.next[strings.Join(letters[max(0, len(letters)-c.order):], "")]
+		if !ok {
+			break
+		}
+		chance := randomIntegerUpTo(options.total)
+		letter := options.letters[len(options.letters)-1]
+		for i, count := range options.counts {
+			if chance < count {
+				letter = options.letters[i]
+				break
+			}
+			chance -= count
+		}
+		if letter == "" {
+			break
+		}
+		letters = append(letters, letter)
+	}
+	return strings.Join(letters, "")
+}
@@ -0,0 +1,144 @@
+package aslanwords_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var canonCorpus = []string{"Hkiyrerao", "Ktiyhui", "Kusyu", "Hiroahkoi", "Ealoa", "Aokhearl", "Kteiroa", "Syoisuis", "Ahroay", "Iyhkoa"}
+
+func TestTrainMarkov_should_count_the_letters_following_the_previous_ones(t *testing.T) {
+	model, err := aslanwords.TrainMarkov([]string{"Ko", "ko", "kea", " "}, 2)
+	require.NoError(t, err)
+
+	expectedModel := &aslanwords.MarkovModel{
+		Order: 2,
+		Transitions: map[string]map[string]int{
+			"":   {"k": 3},
+			"k":  {"o": 2, "e": 1},
+			"ko": {"": 2},
+			"ke": {"a": 1},
+			"ea": {"": 1},
+		},
+	}
+	assert.Equal(t, expectedModel, model)
+}
+
+func TestTrainMarkov_errors(t *testing.T) {
+	testCases := map[string]struct {
+		corpus        []string
+		order         int
+		expectedError string
+	}{
+		"order below one": {canonCorpus, 0, "order must be one or greater"},
+		"empty corpus":    {[]string{"", " "}, 2, "corpus must have at least one word"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.TrainMarkov(tc.corpus, tc.order)
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestWithMarkov_should_generate_words_with_the_letters_of_the_corpus(t *testing.T) {
+	model, err := aslanwords.TrainMarkov(canonCorpus, 2)
+	require.NoError(t, err)
+	gen, err := aslanwords.New(aslanwords.WithMarkov(model), aslanwords.WithSeed(42))
+	require.NoError(t, err)
+
+	for range 100 {
+		assert.Regexp(t, regexp.MustCompile(`^[hkiyreaotusl]+$`), mustGenerate(t, gen))
+	}
+}
+
+func TestWithMarkov_when_called_with_the_same_seed_it_should_always_generate_the_same_word(t *testing.T) {
+	model, err := aslanwords.TrainMarkov(canonCorpus, 2)
+	require.NoError(t, err)
+	ctx := context.Background()
+	expectedWord := aslanwords.MustGenerate(ctx, aslanwords.WithMarkov(model), aslanwords.WithSeed(7))
+
+	for range 10 {
+		assert.Equal(t, expectedWord, aslanwords.MustGenerate(ctx, aslanwords.WithMarkov(model), aslanwords.WithSeed(7)))
+	}
+}
+
+func TestWithValidWordsOnly_should_only_generate_words_following_the_rules(t *testing.T) {
+	model, err := aslanwords.TrainMarkov(append(canonCorpus, "Xyzzy", "Qwerty"), 1)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for seed := range uint64(50) {
+		word, err := aslanwords.GenerateWord(ctx, aslanwords.WithMarkov(model), aslanwords.WithValidWordsOnly(), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.NoError(t, aslanwords.Validate(word.String()))
+		assert.NotEmpty(t, word.Syllables())
+	}
+}
+
+func TestWithMarkov_errors(t *testing.T) {
+	testCases := map[string]struct {
+		model         *aslanwords.MarkovModel
+		expectedError string
+	}{
+		"nil model":           {nil, "markov model cannot be nil"},
+		"order below one":     {&aslanwords.MarkovModel{Transitions: map[string]map[string]int{"": {"a": 1}}}, "order must be one or greater"},
+		"no start":            {&aslanwords.MarkovModel{Order: 1}, "no letter to start a word with"},
+		"context too long":    {&aslanwords.MarkovModel{Order: 1, Transitions: map[string]map[string]int{"": {"a": 1}, "ab": {"": 1}}}, `letters "ab" are more than the order`},
+		"nothing follows":     {&aslanwords.MarkovModel{Order: 1, Transitions: map[string]map[string]int{"": {"a": 1}, "a": {}}}, `letters "a" must be followed`},
+		"not a single letter": {&aslanwords.MarkovModel{Order: 1, Transitions: map[string]map[string]int{"": {"ab": 1}}}, `"ab" following "" is not a single letter`},
+		"count below one":     {&aslanwords.MarkovModel{Order: 1, Transitions: map[string]map[string]int{"": {"a": 0}}}, "must have a count of one or greater"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.New(aslanwords.WithMarkov(tc.model))
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLoadMarkovModel_should_load_a_saved_model(t *testing.T) {
+	model, err := aslanwords.TrainMarkov(canonCorpus, 3)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "model.json")
+
+	require.NoError(t, model.Save(path))
+	loaded, err := aslanwords.LoadMarkovModel(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, model, loaded)
+}
+
+func TestLoadMarkovModel_errors(t *testing.T) {
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed.json")
+	require.NoError(t, os.WriteFile(malformed, []byte("{"), 0o600))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"order": 0}`), 0o600))
+
+	testCases := map[string]struct {
+		path          string
+		expectedError string
+	}{
+		"missing file":   {filepath.Join(dir, "missing.json"), "unable to read the markov model"},
+		"malformed file": {malformed, "unable to parse the markov model"},
+		"invalid model":  {invalid, "invalid markov model: order must be one or greater"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.LoadMarkovModel(tc.path)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
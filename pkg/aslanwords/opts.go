@@ -2,36 +2,103 @@ package aslanwords
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
 )
 
-// WithNumberOfSyllables sets the number of syllables to generate-word
+// WithNumberOfSyllables sets the number of syllables to generate-word.
+// A syllable whose letters are all collapsed with the previous one is merged into it, so len(word.Syllables()) can be
+// lower than n; the word can still be split into n syllables, see Probability.
 func WithNumberOfSyllables(n int) GeneratorOption {
 	return func(o *GeneratorOptions) {
 		o.numberOfSyllablesOpts = fixedAmountOpt{numberOfSyllables: n}
 	}
 }
 
-// WithNumberOfSyllablesBetween Use it to generate-word a random number of syllables between the 'from' and 'to' values
+// WithNumberOfSyllablesBetween Use it to generate-word a random number of syllables between the 'from' and 'to' values.
+// The 'to' value is excluded: WithNumberOfSyllablesBetween(1, 3) generates words of one or two syllables.
+// As with WithNumberOfSyllables, the merged syllables can leave a word with fewer syllables than 'from'.
 func WithNumberOfSyllablesBetween(from, to int) GeneratorOption {
 	return func(o *GeneratorOptions) {
 		o.numberOfSyllablesOpts = randomAmountOpt{from: from, to: to}
 	}
 }
 
+// WithSeed makes the generation reproducible: the same seed with the same options will always generate the same word.
+// Every generator created with the option gets its own source of randomness seeded with it, so the option can be reused.
+func WithSeed(seed uint64) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.seed = &seed
+		o.randSource = nil
+	}
+}
+
+// WithRandSource sets the source of randomness used to choose the number of syllables, the syllables and the letters of the word.
+// A nil source means the global generator of math/rand/v2 will be used.
+// The source is used as it is: generators sharing it, or anyone else using it, must not be used at the same time
+// and take their numbers from the same sequence. Use WithSeed to give each generator its own source.
+func WithRandSource(src rand.Source) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.randSource = src
+		o.seed = nil
+	}
+}
+
+// WithMinEditDistance makes every pair of words of a batch differ in at least n consonants or vowels to insert, delete or replace.
+// The distance is measured on the consonants and vowels of the syllables instead of letters, so "hko" and "kho" are one replacement away.
+func WithMinEditDistance(n int) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.minEditDistance = n
+	}
+}
+
+// WithMaxSharedPrefix makes every pair of words of a batch share at most their first n consonants or vowels.
+// With zero no two words start with the same consonant, or the same vowel.
+func WithMaxSharedPrefix(n int) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.maxSharedPrefix = n
+	}
+}
+
+// WithMaxAttemptsPerWord sets how many generated words can be discarded in a row, because they were already generated
+// or they do not meet the constraints, before giving up
+func WithMaxAttemptsPerWord(n int) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		o.maxAttemptsPerWord = n
+	}
+}
+
 // GeneratorOption Option to configure the generation of Aslan words
 type GeneratorOption func(*GeneratorOptions)
 
 // GeneratorOptions Options to configure the generation of Aslan words
 type GeneratorOptions struct {
 	numberOfSyllablesOpts amountOptions
+	seed                  *uint64
+	randSource            rand.Source
+	maxAttemptsPerWord    int
+	constraints           constraints
+	phonotactics          *phonotactics
+	phonologyErr          error
+	categoryErr           error
+	markov                *markovChain
+	markovErr             error
+	validWordsOnly        bool
+	contentFilter         *contentFilter
+	minEditDistance       int
+	maxSharedPrefix       int
+	style                 Style
+	namespace             string
 }
 
 func newGeneratorOptions() *GeneratorOptions {
 	const defaultMinNumberOfSyllables = 2
 	const defaultMaxNumberOfSyllables = 6
+	const defaultMaxAttemptsPerWord = 1000
 
-	opts := &GeneratorOptions{}
+	opts := &GeneratorOptions{maxAttemptsPerWord: defaultMaxAttemptsPerWord, phonotactics: aslanPhonotactics, maxSharedPrefix: math.MaxInt}
 	WithNumberOfSyllablesBetween(defaultMinNumberOfSyllables, defaultMaxNumberOfSyllables)(opts)
 
 	return opts
@@ -39,23 +106,61 @@ func newGeneratorOptions() *GeneratorOptions {
 
 // Validate checks if the options are valid, returning an error if not
 func (o *GeneratorOptions) Validate() error {
+	if o.categoryErr != nil {
+		return o.categoryErr
+	}
+	if o.phonologyErr != nil {
+		return o.phonologyErr
+	}
+	if o.markovErr != nil {
+		return o.markovErr
+	}
 	if err := o.numberOfSyllablesOpts.Validate(); err != nil {
 		return err
 	}
+	if o.maxAttemptsPerWord < 1 {
+		return fmt.Errorf("max attempts per word must be one or greater")
+	}
+	if o.minEditDistance < 0 {
+		return fmt.Errorf("min edit distance cannot be negative")
+	}
+	if o.maxSharedPrefix < 0 {
+		return fmt.Errorf("max shared prefix cannot be negative")
+	}
+	if err := o.constraints.Validate(o.phonotactics); err != nil {
+		return err
+	}
 	return nil
 }
 
-// numberOfSyllables returns the number of syllables to generate-word
-func (o *GeneratorOptions) numberOfSyllables() int {
+// source returns the source of randomness of a new generator, a new one when the options have a seed
+func (o *GeneratorOptions) source() rand.Source {
+	if o.seed != nil {
+		return rand.NewPCG(*o.seed, *o.seed)
+	}
+	return o.randSource
+}
+
+// numberOfSyllables returns the number of syllables to generate
+func (o *GeneratorOptions) numberOfSyllables(randomIntegerUpTo func(int) int) int {
+	if o.numberOfSyllablesOpts == nil {
+		return 0
+	}
+	return o.numberOfSyllablesOpts.NumberOfSyllables(randomIntegerUpTo)
+}
+
+// maxNumberOfSyllables returns the greatest number of syllables that can be generated
+func (o *GeneratorOptions) maxNumberOfSyllables() int {
 	if o.numberOfSyllablesOpts == nil {
 		return 0
 	}
-	return o.numberOfSyllablesOpts.NumberOfSyllables()
+	return slices.Max(slices.Collect(maps.Keys(o.numberOfSyllablesOpts.Chances())))
 }
 
 type amountOptions interface {
 	Validate() error
-	NumberOfSyllables() int
+	NumberOfSyllables(randomIntegerUpTo func(int) int) int
+	Chances() map[int]float64
 }
 type fixedAmountOpt struct {
 	numberOfSyllables int
@@ -68,10 +173,18 @@ func (s fixedAmountOpt) Validate() error {
 	return nil
 }
 
-func (s fixedAmountOpt) NumberOfSyllables() int {
+func (s fixedAmountOpt) NumberOfSyllables(_ func(int) int) int {
 	return s.numberOfSyllables
 }
 
+// Chances returns the chance of each number of syllables being chosen
+func (s fixedAmountOpt) Chances() map[int]float64 {
+	return map[int]float64{s.numberOfSyllables: 1}
+}
+
+// maxRandomSyllables is the greatest number of syllables WithNumberOfSyllablesBetween can generate
+const maxRandomSyllables = 13
+
 type randomAmountOpt struct {
 	from int
 	to   int
@@ -84,13 +197,28 @@ func (r randomAmountOpt) Validate() error {
 	if r.from > r.to {
 		return fmt.Errorf("number of syllables 'from' cannot be greater than 'to'")
 	}
-	if r.to >= 15 {
+	if r.to > maxRandomSyllables+1 {
 		return fmt.Errorf("number of syllables 'to' cannot be greater than 15")
 	}
 	return nil
 }
 
 // NumberOfSyllables returns a random number of syllables between the 'from' and 'to' values
-func (r randomAmountOpt) NumberOfSyllables() int {
-	return r.from + rand.Intn(r.to-r.from)
+func (r randomAmountOpt) NumberOfSyllables(randomIntegerUpTo func(int) int) int {
+	if r.from == r.to {
+		return r.from
+	}
+	return r.from + randomIntegerUpTo(r.to-r.from)
+}
+
+// Chances returns the chance of each number of syllables being chosen
+func (r randomAmountOpt) Chances() map[int]float64 {
+	if r.from == r.to {
+		return map[int]float64{r.from: 1}
+	}
+	chances := make(map[int]float64, r.to-r.from)
+	for n := r.from; n < r.to; n++ {
+		chances[n] = 1 / float64(r.to-r.from)
+	}
+	return chances
 }
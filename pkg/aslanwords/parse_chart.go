@@ -0,0 +1,389 @@
+package aslanwords
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// parseChart holds the ways a lowercase word can be split into syllables that follow the rules without listing them:
+// for each point of the split, the syllables that can come next and still reach the end of the word.
+// The ways of splitting a word grow exponentially with its length while the points only grow linearly, so the chart is
+// built once and then walked to find the splits, to sum their weights or to tell whether there is any.
+type parseChart struct {
+	p         *phonotactics
+	word      string
+	allVowels bool
+	steps     map[parsePoint][]parseStep
+}
+
+// parsePoint is a point of the split of a word: the letters split so far and the last syllable found
+type parsePoint struct {
+	position int
+	shape    int    // index of the shape of the last syllable, -1 before the first one
+	vowel    string // vowel of the last syllable
+	vanished bool   // the letters of the last syllable have all been collapsed with the previous one
+	first    bool   // the last syllable is the first one of the word
+	final    bool   // the last syllable is the last one of the word
+}
+
+// parseStep is a syllable that can follow a point of the split along with the point it reaches
+type parseStep struct {
+	syllable parsedSyllable
+	next     parsePoint
+}
+
+var chartStart = parsePoint{shape: -1}
+
+// chart returns the ways the lowercase word can be split into syllables that follow the rules
+func (p *phonotactics) chart(word string) *parseChart {
+	c := &parseChart{p: p, word: word, steps: make(map[parsePoint][]parseStep)}
+	c.reachesEnd(chartStart)
+	return c
+}
+
+// preferredChart returns the ways the lowercase word can be split where every syllable has its vowel written,
+// or every way if there is none. Splits with collapsed vowels are the least likely ones and there can be many of them.
+func (p *phonotactics) preferredChart(word string) *parseChart {
+	c := &parseChart{p: p, word: word, allVowels: true, steps: make(map[parsePoint][]parseStep)}
+	if !c.reachesEnd(chartStart) {
+		return p.chart(word)
+	}
+	return c
+}
+
+// reachesEnd tells whether the end of the word can be reached from the point, keeping the steps that reach it
+func (c *parseChart) reachesEnd(at parsePoint) bool {
+	if at.final {
+		return true
+	}
+	if steps, ok := c.steps[at]; ok {
+		return len(steps) > 0
+	}
+	var steps []parseStep
+	for i, shape := range c.p.shapes {
+		if shape.Weight == 0 || at.shape >= 0 && !c.p.rules.CanBeFollowedBy(c.p.shapes[at.shape].Key, shape.Key) {
+			continue
+		}
+		for _, next := range c.p.syllablesAt(c.word, at.position, shape) {
+			if at.shape >= 0 && c.p.repeatsSingleVowelAfter(c.p.shapes[at.shape], at.vowel, next) {
+				continue
+			}
+			// a syllable whose letters are all collapsed with the previous one could be repeated forever, only one is taken
+			if at.vanished && next.length() == 0 || c.allVowels && !next.hasVowel() {
+				continue
+			}
+			// the word may still go on after its last letter with syllables whose letters are all collapsed
+			for _, final := range []bool{true, false} {
+				reached := parsePoint{
+					position: at.position + next.length(),
+					shape:    i,
+					vowel:    next.vowel(),
+					vanished: next.length() == 0,
+					first:    at.shape < 0,
+					final:    final,
+				}
+				if final && reached.position != len(c.word) || !c.p.fits(next, place{first: reached.first, last: final}) {
+					continue
+				}
+				if c.reachesEnd(reached) {
+					steps = append(steps, parseStep{syllable: next, next: reached})
+				}
+			}
+		}
+	}
+	c.steps[at] = steps
+	return len(steps) > 0
+}
+
+// CanBeSplit tells whether the word can be split into syllables that follow the rules
+func (c *parseChart) CanBeSplit() bool {
+	return len(c.steps[chartStart]) > 0
+}
+
+// chanceKey tells apart the ways of reaching a point by the number of syllables and the length of the chain of vowels
+// the last syllable ends. Only whether the chain has a single vowel and its parity matter, so chains of an odd length
+// greater than one are all 3.
+type chanceKey struct {
+	syllables int
+	chain     int
+}
+
+// Chance returns the chance of generating the word given the chance of each number of syllables. It is the sum of the
+// weights of every way of splitting the word, like Weight, but it adds them up point by point instead of split by split.
+// The chance of a chain of vowels depends on the vowel swap picked for the whole chain, so the chance of reaching a point
+// is kept for the whole vowel table first and then for each vowel swap until the chain ends.
+func (c *parseChart) Chance(chances map[int]float64) float64 {
+	maxSyllables := 0
+	for n := range chances {
+		maxSyllables = max(maxSyllables, n)
+	}
+	start := make([]float64, 1+len(c.p.vowelSwaps))
+	for i := range start {
+		start[i] = 1
+	}
+	reached := map[parsePoint]map[chanceKey][]float64{chartStart: {{}: start}}
+	total := 0.0
+	for _, at := range c.points() {
+		for key, vectors := range reached[at] {
+			if at.final {
+				total += chances[key.syllables] * c.p.chainChance(vectors, key.chain)
+				continue
+			}
+			if key.syllables == maxSyllables {
+				continue
+			}
+			for _, step := range c.steps[at] {
+				nextKey, nextVectors := c.advance(at, key, vectors, step)
+				if reached[step.next] == nil {
+					reached[step.next] = make(map[chanceKey][]float64)
+				}
+				if existing, ok := reached[step.next][nextKey]; ok {
+					for i := range existing {
+						existing[i] += nextVectors[i]
+					}
+					continue
+				}
+				reached[step.next][nextKey] = nextVectors
+			}
+		}
+	}
+	return total
+}
+
+// points returns the points that reach the end of the word in an order where every point comes after the points leading to it.
+// Every syllable moves the split forward but the ones that vanish, which are never followed by another one that vanishes.
+func (c *parseChart) points() []parsePoint {
+	points := []parsePoint{chartStart}
+	seen := map[parsePoint]bool{chartStart: true}
+	for i := 0; i < len(points); i++ {
+		for _, step := range c.steps[points[i]] {
+			if !seen[step.next] {
+				seen[step.next] = true
+				points = append(points, step.next)
+			}
+		}
+	}
+	slices.SortStableFunc(points, func(a, b parsePoint) int {
+		return cmp.Or(cmp.Compare(a.position, b.position), cmp.Compare(flag(a.vanished), flag(b.vanished)))
+	})
+	return points
+}
+
+// advance returns the chances of reaching the point of the step from the chances of reaching the given one
+func (c *parseChart) advance(at parsePoint, key chanceKey, vectors []float64, step parseStep) (chanceKey, []float64) {
+	weight := c.stepWeight(at, step)
+	vowel := step.syllable.vowel()
+	next := make([]float64, len(vectors))
+	if c.continuesChain(at, step) {
+		// the vowel is the one after the chain, the odd ones are picked from the next vowels of the swap
+		for table := range next {
+			next[table] = weight * vectors[table] * c.p.vowelTable(table, key.chain).chance(vowel)
+		}
+		return chanceKey{syllables: key.syllables + 1, chain: nextChain(key.chain)}, next
+	}
+	chain := c.p.chainChance(vectors, key.chain)
+	for table := range next {
+		next[table] = weight * chain * c.p.vowelTable(table, 0).chance(vowel)
+	}
+	return chanceKey{syllables: key.syllables + 1, chain: 1}, next
+}
+
+// stepWeight returns the chance of picking the shape and the consonants of the syllable of the step
+func (c *parseChart) stepWeight(at parsePoint, step parseStep) float64 {
+	s := step.syllable
+	previousKey := ""
+	if at.shape >= 0 {
+		previousKey = c.p.shapes[at.shape].Key
+	}
+	weight := c.p.shapeChance(previousKey, s.shape)
+	for j, kind := range s.shape.Slots {
+		if kind != syllable.VowelSlot {
+			weight *= c.p.slotOptionsAt(kind, place{first: step.next.first, last: step.next.final}).chance(s.options[j])
+		}
+	}
+	return weight
+}
+
+// continuesChain tells whether the vowel of the syllable of the step continues the chain of vowels ended at the point
+func (c *parseChart) continuesChain(at parsePoint, step parseStep) bool {
+	return at.shape >= 0 && endsWithVowel(c.p.shapes[at.shape]) && step.syllable.startsWithVowel()
+}
+
+// vowelTable returns the vowels of the table, 0 for the whole table and the rest for the vowel swaps, picked by the vowel
+// after a chain of the given length. Vowels after a chain of odd length are picked from the next vowels of the swap.
+func (p *phonotactics) vowelTable(table, chain int) weights {
+	switch {
+	case table == 0:
+		return p.vowels
+	case chain%2 == 1:
+		return p.vowelSwaps[table-1].nextVowels
+	default:
+		return p.vowelSwaps[table-1].vowels
+	}
+}
+
+// nextChain returns the length of the chain of vowels once a vowel is added to a chain of the given length
+func nextChain(chain int) int {
+	if chain%2 == 1 {
+		return 2
+	}
+	return 3
+}
+
+// chainChance returns the chance of reaching a point along with the chance of generating the chain of vowels it ends, as vowelChainChance does
+func (p *phonotactics) chainChance(vectors []float64, chain int) float64 {
+	if chain <= 1 || len(p.vowelSwaps) == 0 {
+		return vectors[0]
+	}
+	total := 0.0
+	for _, chance := range vectors[1:] {
+		total += chance
+	}
+	return total / float64(len(p.vowelSwaps))
+}
+
+// closedChance returns the chance of a chain of vowels of the given length generated with a single vowel table once it ends,
+// given the chance of generating it with that table. Chains of a single vowel are picked from the whole table and longer
+// ones from one of the vowel swaps, so the chance of the other tables is 0.
+func (c *parseChart) closedChance(chain, table int, chance float64) float64 {
+	switch {
+	case chain <= 1 || len(c.p.vowelSwaps) == 0:
+		if table == 0 {
+			return chance
+		}
+		return 0
+	case table == 0:
+		return 0
+	default:
+		return chance / float64(len(c.p.vowelSwaps))
+	}
+}
+
+// parsePath is a way of splitting the letters up to a point: its last syllable and the path to the previous point
+type parsePath struct {
+	syllable parsedSyllable
+	previous *parsePath
+}
+
+// parse returns the syllables of the path
+func (path *parsePath) parse() parse {
+	var found parse
+	for ; path != nil && path.previous != nil; path = path.previous {
+		found = append(found, path.syllable)
+	}
+	slices.Reverse(found)
+	return found
+}
+
+// bounds returns, for each point, length of the chain of vowels ended at it and vowel table, the greatest factor the chance
+// of reaching the point with that table can be multiplied by on the way to the end of the word. It is the Viterbi algorithm
+// walked backwards, adding up the vowel tables of a new chain instead of taking the greatest one, so the chance of every
+// way of going on from a point is never greater than the chances of reaching it times these factors.
+func (c *parseChart) bounds() map[parsePoint][][]float64 {
+	tables := 1 + len(c.p.vowelSwaps)
+	points := c.points()
+	bounds := make(map[parsePoint][][]float64, len(points))
+	for _, at := range slices.Backward(points) {
+		factors := make([][]float64, 4)
+		for chain := range factors {
+			factors[chain] = make([]float64, tables)
+			if at.final {
+				for table := range tables {
+					factors[chain][table] = c.closedChance(chain, table, 1)
+				}
+			}
+		}
+		for _, step := range c.steps[at] {
+			weight := c.stepWeight(at, step)
+			vowel := step.syllable.vowel()
+			next := bounds[step.next]
+			if c.continuesChain(at, step) {
+				for chain := range factors {
+					for table := range tables {
+						if table == 0 && tables > 1 {
+							continue
+						}
+						factor := weight * c.p.vowelTable(table, chain).chance(vowel) * next[nextChain(chain)][table]
+						factors[chain][table] = max(factors[chain][table], factor)
+					}
+				}
+				continue
+			}
+			newChain := 0.0
+			for table := range tables {
+				newChain += c.p.vowelTable(table, 0).chance(vowel) * next[1][table]
+			}
+			for chain := range factors {
+				for table := range tables {
+					factors[chain][table] = max(factors[chain][table], c.closedChance(chain, table, weight*newChain))
+				}
+			}
+		}
+		bounds[at] = factors
+	}
+	return bounds
+}
+
+// rankedPath is a way of generating the syllables up to a point along with the chances of generating them with each
+// vowel table, as Chance keeps them, and the greatest chance the word can have going on from there
+type rankedPath struct {
+	at      parsePoint
+	key     chanceKey
+	vectors []float64
+	path    *parsePath
+	bound   float64
+}
+
+// rankedPaths is a priority queue of paths where the path with the greatest bound comes first
+type rankedPaths []*rankedPath
+
+func (q rankedPaths) Len() int           { return len(q) }
+func (q rankedPaths) Less(i, j int) bool { return q[i].bound > q[j].bound }
+func (q rankedPaths) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *rankedPaths) Push(x any)        { *q = append(*q, x.(*rankedPath)) }
+func (q *rankedPaths) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
+
+// Rank visits the ways of splitting the word from the most to the least likely, along with their weight as Weight returns it,
+// until the visit function returns false. Splits that cannot be generated are not visited.
+// Ways of going on from a point are tried in the order of the greatest chance they can reach, given by bounds, so a split
+// is only visited once no other way can reach a greater chance, and the splits that cannot be among the most likely ones
+// are never walked.
+func (c *parseChart) Rank(visit func(parse, float64) bool) {
+	if !c.CanBeSplit() {
+		return
+	}
+	bounds := c.bounds()
+	start := make([]float64, 1+len(c.p.vowelSwaps))
+	for i := range start {
+		start[i] = 1
+	}
+	queue := &rankedPaths{{at: chartStart, vectors: start, path: &parsePath{}, bound: 1}}
+	for queue.Len() > 0 {
+		ranked := heap.Pop(queue).(*rankedPath)
+		if ranked.at.final {
+			if !visit(ranked.path.parse(), ranked.bound) {
+				return
+			}
+			continue
+		}
+		for _, step := range c.steps[ranked.at] {
+			key, vectors := c.advance(ranked.at, ranked.key, ranked.vectors, step)
+			bound := 0.0
+			for table, chance := range vectors {
+				bound += chance * bounds[step.next][key.chain][table]
+			}
+			if bound > 0 {
+				path := &parsePath{syllable: step.syllable, previous: ranked.path}
+				heap.Push(queue, &rankedPath{at: step.next, key: key, vectors: vectors, path: path, bound: bound})
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package aslanwords
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+	"gopkg.in/yaml.v3"
+)
+
+// Phonology is the inventory of consonants and vowels, with their weights, along with the weight of each kind of syllable
+// words are generated from
+type Phonology = syllable.Phonology
+
+// Phoneme is a consonant or vowel of a Phonology along with the weight used to pick it, from one to MaxPhonemeWeight
+type Phoneme = syllable.Phoneme
+
+// MaxPhonemeWeight is the greatest weight of a Phoneme
+const MaxPhonemeWeight = syllable.MaxPhonemeWeight
+
+// Positions holds the consonants used instead of the ones of a Phonology by the syllables at the start, in the middle or at the end of the words
+type Positions = syllable.Positions
+
+// PositionalConsonants are the consonants the syllables at a position of the word start and end with, see Positions
+type PositionalConsonants = syllable.PositionalConsonants
+
+// AslanPhonology returns the phonology of the Aslan language, the one used by default.
+// It is a good starting point to tweak the weights of the letters.
+func AslanPhonology() Phonology {
+	return syllable.AslanPhonology()
+}
+
+// WithPhonology generates words with the given phonology instead of the Aslan one
+func WithPhonology(phonology Phonology) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		rules, err := syllable.NewRules(phonology)
+		if err != nil {
+			o.phonologyErr = err
+			return
+		}
+		o.phonologyErr = nil
+		o.phonotactics = newPhonotactics(rules)
+	}
+}
+
+// LoadPhonology reads a phonology from a JSON or YAML file, depending on its extension, and checks it is valid
+func LoadPhonology(path string) (Phonology, error) {
+	unmarshal, err := fileUnmarshaler(path, "phonology")
+	if err != nil {
+		return Phonology{}, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Phonology{}, fmt.Errorf("unable to read the phonology: %w", err)
+	}
+	return parsePhonology(content, unmarshal)
+}
+
+// fileUnmarshaler returns the function to decode a file depending on its extension, the kind of content is used in the error
+func fileUnmarshaler(path, kind string) (func([]byte, any) error, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return json.Unmarshal, nil
+	case ".yaml", ".yml":
+		return yaml.Unmarshal, nil
+	default:
+		return nil, fmt.Errorf("unsupported %s file extension %q, use .json, .yaml or .yml", kind, ext)
+	}
+}
+
+func parsePhonology(content []byte, unmarshal func([]byte, any) error) (Phonology, error) {
+	var phonology Phonology
+	if err := unmarshal(content, &phonology); err != nil {
+		return Phonology{}, fmt.Errorf("unable to parse the phonology: %w", err)
+	}
+	if err := phonology.Validate(); err != nil {
+		return Phonology{}, fmt.Errorf("invalid phonology: %w", err)
+	}
+	return phonology, nil
+}
+
+// WithPhonologyFile generates words with the phonology read from a JSON or YAML file, see LoadPhonology
+func WithPhonologyFile(path string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		phonology, err := LoadPhonology(path)
+		if err != nil {
+			o.phonologyErr = err
+			return
+		}
+		WithPhonology(phonology)(o)
+	}
+}
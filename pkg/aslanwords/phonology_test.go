@@ -0,0 +1,187 @@
+package aslanwords_test
+
+import (
+	"context"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPhonology_when_not_used_it_should_generate_the_same_words_as_the_aslan_phonology(t *testing.T) {
+	ctx := context.Background()
+
+	for seed := range uint64(20) {
+		expectedWord := aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed))
+
+		word := aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed), aslanwords.WithPhonology(aslanwords.AslanPhonology()))
+
+		assert.Equal(t, expectedWord, word)
+	}
+}
+
+func TestWithPhonology_it_should_only_generate_words_with_its_phonemes(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}, {Text: "t", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 1}, {Text: "e", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"V": 1, "CV": 1, "VC": 1, "CVC": 1},
+	}
+	gen, err := aslanwords.New(aslanwords.WithPhonology(phonology))
+	require.NoError(t, err)
+
+	for range 200 {
+		assert.Regexp(t, regexp.MustCompile(`^[kaetr]+$`), mustGenerate(t, gen))
+	}
+}
+
+func TestWithPhonology_when_the_phonology_is_invalid_it_should_return_error(t *testing.T) {
+	phonology := aslanwords.AslanPhonology()
+	phonology.Vowels = nil
+
+	_, err := aslanwords.New(aslanwords.WithPhonology(phonology))
+
+	assert.ErrorContains(t, err, "invalid phonology")
+}
+
+func TestWithPhonology_constraints_should_be_checked_against_its_phonemes(t *testing.T) {
+	phonology := aslanwords.AslanPhonology()
+	phonology.FirstConsonants = append(phonology.FirstConsonants, aslanwords.Phoneme{Text: "zh", Weight: 1})
+
+	word, err := aslanwords.Generate(context.Background(), aslanwords.WithPhonology(phonology), aslanwords.WithPrefix("zh"))
+
+	require.NoError(t, err)
+	assert.Regexp(t, "^zh", word)
+}
+
+func TestLoadPhonology(t *testing.T) {
+	expectedPhonology := aslanwords.Phonology{
+		FirstConsonants:           []aslanwords.Phoneme{{Text: "k", Weight: 3}, {Text: "t", Weight: 1}},
+		Vowels:                    []aslanwords.Phoneme{{Text: "a", Weight: 2}, {Text: "e", Weight: 1}, {Text: "ai", Weight: 1}},
+		LastConsonants:            []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights:           map[string]int{"V": 1, "CV": 3, "VC": 1, "CVC": 1},
+		Followers:                 map[string][]string{"VC": {"V", "VC"}, "CVC": {"V", "VC"}},
+		AvoidRepeatedSingleVowels: true,
+	}
+
+	for _, path := range []string{"testdata/phonology.json", "testdata/phonology.yaml"} {
+		t.Run(path, func(t *testing.T) {
+			phonology, err := aslanwords.LoadPhonology(path)
+
+			require.NoError(t, err)
+			assert.Equal(t, expectedPhonology, phonology)
+		})
+	}
+}
+
+func TestLoadPhonology_with_positions(t *testing.T) {
+	phonology, err := aslanwords.LoadPhonology("testdata/positional_phonology.yaml")
+
+	require.NoError(t, err)
+	assert.Equal(t, aslanwords.Positions{
+		Initial: aslanwords.PositionalConsonants{
+			FirstConsonants: []aslanwords.Phoneme{{Text: "t", Weight: 1}},
+			LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		},
+		Final: aslanwords.PositionalConsonants{LastConsonants: []aslanwords.Phoneme{{Text: "s", Weight: 1}}},
+	}, phonology.Positions)
+}
+
+func TestLoadPhonology_errors(t *testing.T) {
+	testCases := map[string]struct {
+		path          string
+		expectedError string
+	}{
+		"missing file":          {"testdata/missing.json", "unable to read the phonology"},
+		"malformed file":        {"testdata/malformed_phonology.json", "unable to parse the phonology"},
+		"unsupported extension": {"testdata/phonology.toml", "unsupported phonology file extension \".toml\""},
+		"invalid phonology":     {"testdata/invalid_phonology.json", "invalid phonology: invalid vowels"},
+		"weight too great":      {"testdata/heavy_phonology.json", `invalid phonology: invalid first consonants: weight of phoneme "k" cannot be greater than 1000`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := aslanwords.LoadPhonology(tc.path)
+
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestWithPhonologyFile_it_should_generate_words_with_the_phonology_of_the_file(t *testing.T) {
+	gen, err := aslanwords.New(aslanwords.WithPhonologyFile("testdata/phonology.yaml"))
+	require.NoError(t, err)
+
+	for range 100 {
+		assert.Regexp(t, regexp.MustCompile(`^[ktaeir]+$`), mustGenerate(t, gen))
+	}
+}
+
+func TestWithPhonologyFile_when_the_file_cannot_be_loaded_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.New(aslanwords.WithPhonologyFile("testdata/missing.yaml"))
+
+	assert.ErrorContains(t, err, "unable to read the phonology")
+}
+
+func TestWithPhonology_with_positions_it_should_use_the_consonants_of_the_position_of_each_syllable(t *testing.T) {
+	phonology := aslanwords.AslanPhonology()
+	phonology.Positions.Initial.FirstConsonants = []aslanwords.Phoneme{{Text: "kh", Weight: 3}, {Text: "ht", Weight: 2}}
+	phonology.Positions.Final.LastConsonants = []aslanwords.Phoneme{{Text: "h", Weight: 2}, {Text: "r", Weight: 1}}
+	gen, err := aslanwords.New(aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllablesBetween(1, 5))
+	require.NoError(t, err)
+
+	for range 200 {
+		word, err := gen.GenerateWord(context.Background())
+		require.NoError(t, err)
+
+		parts := word.Parts()
+		assert.Contains(t, []string{"", "kh", "ht"}, parts[0].FirstConsonant, word.String())
+		assert.Contains(t, []string{"", "h", "r"}, parts[len(parts)-1].LastConsonant, word.String())
+	}
+}
+
+func TestWithPhonology_with_positions_words_should_be_counted_and_weighted_by_position(t *testing.T) {
+	// the first syllable starts with t and may end with r, the last one starts with k and may end with s,
+	// and the ones in the middle start with k and may end with l
+	testCases := map[string]struct {
+		numberOfSyllables   int
+		expectedSize        int64
+		word                string
+		expectedProbability float64
+	}{
+		"one syllable is both the first and the last one":   {1, 4, "tas", 0.25},
+		"one syllable cannot end with an initial consonant": {1, 4, "tar", 0},
+		"two syllables":                      {2, 16, "tarkes", 1.0 / 16},
+		"two syllables cannot start with k":  {2, 16, "karkes", 0},
+		"three syllables":                    {3, 64, "takelkes", 1.0 / 64},
+		"medial syllables cannot end with s": {3, 64, "takeskes", 0},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			options := []aslanwords.GeneratorOption{
+				aslanwords.WithPhonologyFile("testdata/positional_phonology.yaml"),
+				aslanwords.WithNumberOfSyllables(tc.numberOfSyllables),
+			}
+
+			space, err := aslanwords.SpaceSize(context.Background(), options...)
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(tc.expectedSize), space.Size)
+
+			probability, err := aslanwords.Probability(tc.word, options...)
+			require.NoError(t, err)
+			assert.InDelta(t, tc.expectedProbability, probability, 1e-12)
+
+			count, totalWeight := 0, 0.0
+			for word, err := range aslanwords.Enumerate(context.Background(), tc.numberOfSyllables, options...) {
+				require.NoError(t, err)
+				count++
+				totalWeight += word.Weight
+			}
+			assert.EqualValues(t, tc.expectedSize, count)
+			assert.InDelta(t, 1, totalWeight, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package aslanwords
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+var aslanPhonotactics = newPhonotactics(syllable.AslanRules())
+
+// phonotactics holds the rules of the syllable tables in the shape needed to check and split existing words.
+// Consonants and vowels of the Aslan language do not share letters, so any word is a sequence of runs of consonants and runs of vowels:
+// each run of consonants is the start of a syllable, the end of a syllable or both, and each run of vowels is split into the vowels of consecutive syllables.
+// When the consonants depend on where the syllable is in the word, the first and last consonants are the ones of every position.
+type phonotactics struct {
+	rules                                  *syllable.Rules
+	shapes                                 []syllable.Shape
+	firstConsonants                        weights
+	vowels                                 weights
+	lastConsonants                         weights
+	positional                             bool
+	placedConsonants                       map[place]map[syllable.SlotKind]weights
+	vowelSwaps                             []vowelSwap
+	singleVowels                           map[string]bool
+	consonantLetters                       map[rune]bool
+	vowelLetters                           map[rune]bool
+	lastConsonantCanBeFollowedByConsonants bool
+	sortedOptions                          map[syllable.SlotKind][]string
+}
+
+type vowelSwap struct {
+	vowels     weights
+	nextVowels weights
+}
+
+func newPhonotactics(rules *syllable.Rules) *phonotactics {
+	p := &phonotactics{
+		rules:                                  rules,
+		shapes:                                 rules.Shapes(),
+		firstConsonants:                        rules.FirstConsonants(),
+		vowels:                                 rules.Vowels(),
+		lastConsonants:                         rules.LastConsonants(),
+		singleVowels:                           make(map[string]bool),
+		consonantLetters:                       make(map[rune]bool),
+		vowelLetters:                           make(map[rune]bool),
+		lastConsonantCanBeFollowedByConsonants: rules.CanBeFollowedBy("VC", "CV"),
+		positional:                             rules.HasPositions(),
+	}
+	if p.positional {
+		p.placedConsonants = make(map[place]map[syllable.SlotKind]weights)
+		p.firstConsonants, p.lastConsonants = weights{}, weights{}
+		for _, at := range places {
+			p.placedConsonants[at] = map[syllable.SlotKind]weights{
+				syllable.FirstConsonantSlot: rules.ConsonantsAt(syllable.FirstConsonantSlot, at.first, at.last),
+				syllable.LastConsonantSlot:  rules.ConsonantsAt(syllable.LastConsonantSlot, at.first, at.last),
+			}
+			p.firstConsonants = union(p.firstConsonants, p.placedConsonants[at][syllable.FirstConsonantSlot])
+			p.lastConsonants = union(p.lastConsonants, p.placedConsonants[at][syllable.LastConsonantSlot])
+		}
+	}
+	p.sortedOptions = map[syllable.SlotKind][]string{
+		syllable.FirstConsonantSlot: slices.Sorted(maps.Keys(p.firstConsonants)),
+		syllable.VowelSlot:          slices.Sorted(maps.Keys(p.vowels)),
+		syllable.LastConsonantSlot:  slices.Sorted(maps.Keys(p.lastConsonants)),
+	}
+	for _, swap := range rules.VowelSwaps() {
+		p.vowelSwaps = append(p.vowelSwaps, vowelSwap{vowels: swap.Vowels, nextVowels: swap.NextVowels})
+	}
+	for _, v := range rules.SingleVowels() {
+		p.singleVowels[v] = true
+	}
+	for _, consonants := range []weights{p.firstConsonants, p.lastConsonants} {
+		for consonant := range consonants {
+			for _, letter := range consonant {
+				p.consonantLetters[letter] = true
+			}
+		}
+	}
+	for v := range p.vowels {
+		for _, letter := range v {
+			p.vowelLetters[letter] = true
+		}
+	}
+	return p
+}
+
+// slotOptions returns the letters a slot of the given kind can be made of along with their weight
+func (p *phonotactics) slotOptions(kind syllable.SlotKind) weights {
+	switch kind {
+	case syllable.FirstConsonantSlot:
+		return p.firstConsonants
+	case syllable.LastConsonantSlot:
+		return p.lastConsonants
+	default:
+		return p.vowels
+	}
+}
+
+// place is where a syllable is in its word: whether it is the first one and whether it is the last one
+type place struct {
+	first bool
+	last  bool
+}
+
+var places = []place{{first: true, last: true}, {first: true}, {last: true}, {}}
+
+// placeOf returns the place of the syllable at the index of a word with the given number of syllables
+func placeOf(index, numberOfSyllables int) place {
+	return place{first: index == 0, last: index == numberOfSyllables-1}
+}
+
+// slotOptionsAt returns the letters a slot of the given kind can be made of in a syllable at the given place of the word
+func (p *phonotactics) slotOptionsAt(kind syllable.SlotKind, at place) weights {
+	if !p.positional || kind == syllable.VowelSlot {
+		return p.slotOptions(kind)
+	}
+	return p.placedConsonants[at][kind]
+}
+
+// fits tells whether the consonants of the syllable can be the ones of a syllable at the given place of the word
+func (p *phonotactics) fits(s parsedSyllable, at place) bool {
+	if !p.positional {
+		return true
+	}
+	for i, kind := range s.shape.Slots {
+		if _, ok := p.slotOptionsAt(kind, at)[s.options[i]]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedSlotOptions returns the letters a slot of the given kind can be made of in alphabetical order
+func (p *phonotactics) sortedSlotOptions(kind syllable.SlotKind) []string {
+	return p.sortedOptions[kind]
+}
+
+// shapeChance returns the chance of picking the shape after a syllable with the previous key, the first syllable has no previous key
+func (p *phonotactics) shapeChance(previousKey string, shape syllable.Shape) float64 {
+	if previousKey != "" && !p.rules.CanBeFollowedBy(previousKey, shape.Key) {
+		return 0
+	}
+	totalWeight := 0
+	for _, candidate := range p.shapes {
+		if previousKey == "" || p.rules.CanBeFollowedBy(previousKey, candidate.Key) {
+			totalWeight += candidate.Weight
+		}
+	}
+	return float64(shape.Weight) / float64(totalWeight)
+}
+
+// vowelChainChance returns the chance of generating the vowels of consecutive syllables where each one ends with vowel
+// and the next one starts with vowel. A swap is picked for the whole chain and its tables alternate from one vowel to the next.
+// Without swaps every vowel is picked from the whole table.
+func (p *phonotactics) vowelChainChance(vowels []string) float64 {
+	switch len(vowels) {
+	case 0:
+		return 1
+	case 1:
+		return p.vowels.chance(vowels[0])
+	}
+	if len(p.vowelSwaps) == 0 {
+		chance := 1.0
+		for _, v := range vowels {
+			chance *= p.vowels.chance(v)
+		}
+		return chance
+	}
+	total := 0.0
+	for _, swap := range p.vowelSwaps {
+		chance := 1.0
+		for i, v := range vowels {
+			if i%2 == 0 {
+				chance *= swap.vowels.chance(v)
+			} else {
+				chance *= swap.nextVowels.chance(v)
+			}
+		}
+		total += chance
+	}
+	return total / float64(len(p.vowelSwaps))
+}
+
+// collapses tells whether two consecutive copies of the letter are collapsed into one in a generated word
+func collapses(letter byte) bool {
+	return collapse(string([]byte{letter, letter})) == string(letter)
+}
+
+// weights are the options of a slot with the number of times each one appears in its template
+type weights map[string]int
+
+// union returns the options of both weights, with the highest weight of each option
+func union(a, b weights) weights {
+	merged := maps.Clone(a)
+	for option, weight := range b {
+		merged[option] = max(merged[option], weight)
+	}
+	return merged
+}
+
+func (w weights) total() int {
+	total := 0
+	for _, weight := range w {
+		total += weight
+	}
+	return total
+}
+
+// chance returns the chance of picking the option
+func (w weights) chance(option string) float64 {
+	total := w.total()
+	if total == 0 {
+		return 0
+	}
+	return float64(w[option]) / float64(total)
+}
@@ -0,0 +1,22 @@
+package aslanwords
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Probability returns the chance of generating the word, ignoring the case of its letters, with the given options.
+// It sums the chances of every way the word can be split into syllables, so it is 0 for words that cannot be generated.
+// Constraints like WithPrefix are not taken into account: it is the chance of a single generated word being this one.
+func Probability(word string, opts ...GeneratorOption) (float64, error) {
+	options := newGeneratorOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	if err := options.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid options: %w", err)
+	}
+
+	chances := options.numberOfSyllablesOpts.Chances()
+	return options.phonotactics.chart(strings.ToLower(word)).Chance(chances), nil
+}
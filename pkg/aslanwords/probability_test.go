@@ -0,0 +1,82 @@
+package aslanwords_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbability_should_be_the_chance_of_generating_the_word(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "a", Weight: 2}, {Text: "e", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+
+	testCases := map[string]struct {
+		word                string
+		opts                []aslanwords.GeneratorOption
+		expectedProbability float64
+	}{
+		"single syllable":            {"ka", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(1)}, 2.0 / 3},
+		"ignoring the case":          {"KE", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(1)}, 1.0 / 3},
+		"many syllables":             {"kake", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(2)}, 2.0 / 9},
+		"chance of the syllables":    {"kake", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllablesBetween(1, 3)}, 1.0 / 9},
+		"wrong number of syllables":  {"kake", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(1)}, 0},
+		"word that cannot be formed": {"kar", []aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(1)}, 0},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			probability, err := aslanwords.Probability(tc.word, append(tc.opts, aslanwords.WithPhonology(phonology))...)
+
+			require.NoError(t, err)
+			assert.InDelta(t, tc.expectedProbability, probability, 1e-9)
+		})
+	}
+}
+
+func TestProbability_should_match_the_frequency_of_generated_words(t *testing.T) {
+	const numberOfWords = 20000
+	gen, err := aslanwords.New(aslanwords.WithNumberOfSyllables(1), aslanwords.WithSeed(42))
+	require.NoError(t, err)
+	frequencies := make(map[string]int)
+	for range numberOfWords {
+		frequencies[mustGenerate(t, gen)]++
+	}
+
+	for _, word := range []string{"a", "e", "ao", "ko"} {
+		probability, err := aslanwords.Probability(word, aslanwords.WithNumberOfSyllables(1))
+		require.NoError(t, err)
+
+		assert.InDelta(t, probability, float64(frequencies[word])/numberOfWords, 0.01, word)
+	}
+}
+
+func TestProbability_when_options_are_invalid_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.Probability("ko", aslanwords.WithNumberOfSyllables(0))
+
+	assert.ErrorContains(t, err, "invalid options")
+}
+
+func TestProbability_of_a_long_word_should_add_up_every_split_quickly(t *testing.T) {
+	const word = "eaoaiaoaeaoaiaoaeaoa"
+
+	start := time.Now()
+	probability, err := aslanwords.Probability(word, aslanwords.WithNumberOfSyllablesBetween(1, 14))
+	require.NoError(t, err)
+	elapsed := time.Since(start)
+
+	expectedProbability := 0.0
+	for n := 1; n <= 13; n++ {
+		chance, err := aslanwords.Probability(word, aslanwords.WithNumberOfSyllables(n))
+		require.NoError(t, err)
+		expectedProbability += chance / 13
+	}
+	assert.Positive(t, probability)
+	assert.InDelta(t, expectedProbability, probability, expectedProbability*1e-9)
+	assert.Less(t, elapsed, time.Second)
+}
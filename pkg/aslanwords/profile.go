@@ -0,0 +1,169 @@
+package aslanwords
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// AslanProfile is the name of the profile of the Aslan language, the one used by default
+const AslanProfile = "aslan"
+
+// ErrUnknownProfile is returned when the profile used to generate words has not been registered
+var ErrUnknownProfile = errors.New("unknown profile")
+
+//go:embed profiles/*.yaml
+var builtinProfiles embed.FS
+
+var profiles = newProfileRegistry()
+
+// Profile is a language words can be generated for, like the language of a Traveller species or a homebrew one
+type Profile interface {
+	// Name identifies the profile, it is not case-sensitive
+	Name() string
+	// Phonology returns the letters and the kinds of syllable the words of the language are made of
+	Phonology() Phonology
+}
+
+// NewProfile creates a profile with the given name and phonology
+func NewProfile(name string, phonology Phonology) Profile {
+	return phonologyProfile{name: name, phonology: phonology}
+}
+
+type phonologyProfile struct {
+	name      string
+	phonology Phonology
+}
+
+func (p phonologyProfile) Name() string {
+	return p.name
+}
+
+func (p phonologyProfile) Phonology() Phonology {
+	return p.phonology
+}
+
+// RegisterProfile makes the profile available to WithProfile. Its name cannot be already registered and its phonology must be valid.
+// The built-in profiles are aslan, vargr, vilani and zhodani.
+func RegisterProfile(profile Profile) error {
+	return profiles.register(profile)
+}
+
+// LookupProfile returns the registered profile with the given name
+func LookupProfile(name string) (Profile, bool) {
+	entry, ok := profiles.lookup(name)
+	if !ok {
+		return nil, false
+	}
+	return entry.profile, true
+}
+
+// Profiles returns the names of the registered profiles sorted alphabetically
+func Profiles() []string {
+	return profiles.names()
+}
+
+// WithProfile generates words of the registered profile with the given name instead of Aslan words
+func WithProfile(name string) GeneratorOption {
+	return func(o *GeneratorOptions) {
+		entry, ok := profiles.lookup(name)
+		if !ok {
+			o.phonologyErr = fmt.Errorf("%w %q, it must be one of %s", ErrUnknownProfile, name, strings.Join(profiles.names(), ", "))
+			return
+		}
+		o.phonologyErr = nil
+		o.phonotactics = entry.phonotactics
+	}
+}
+
+type profileEntry struct {
+	profile      Profile
+	phonotactics *phonotactics
+}
+
+// profileRegistry holds the profiles by their lowercase name, compiling their phonology once on registration
+type profileRegistry struct {
+	mu      sync.RWMutex
+	entries map[string]profileEntry
+}
+
+func newProfileRegistry() *profileRegistry {
+	r := &profileRegistry{entries: make(map[string]profileEntry)}
+	r.mustRegister(profileEntry{profile: NewProfile(AslanProfile, AslanPhonology()), phonotactics: aslanPhonotactics})
+
+	files, err := builtinProfiles.ReadDir("profiles")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		content, err := builtinProfiles.ReadFile(path.Join("profiles", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		unmarshal, err := fileUnmarshaler(file.Name(), "phonology")
+		if err != nil {
+			panic(err)
+		}
+		phonology, err := parsePhonology(content, unmarshal)
+		if err != nil {
+			panic(fmt.Errorf("built-in profile %s: %w", file.Name(), err))
+		}
+		name := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		if err := r.register(NewProfile(name, phonology)); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func (r *profileRegistry) register(profile Profile) error {
+	if profile == nil || strings.TrimSpace(profile.Name()) == "" {
+		return errors.New("profile must have a name")
+	}
+	rules, err := syllable.NewRules(profile.Phonology())
+	if err != nil {
+		return fmt.Errorf("profile %s: %w", profile.Name(), err)
+	}
+	return r.add(profileEntry{profile: profile, phonotactics: newPhonotactics(rules)})
+}
+
+func (r *profileRegistry) mustRegister(entry profileEntry) {
+	if err := r.add(entry); err != nil {
+		panic(err)
+	}
+}
+
+func (r *profileRegistry) add(entry profileEntry) error {
+	key := strings.ToLower(entry.profile.Name())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[key]; ok {
+		return fmt.Errorf("profile %s is already registered", entry.profile.Name())
+	}
+	r.entries[key] = entry
+	return nil
+}
+
+func (r *profileRegistry) lookup(name string) (profileEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.entries[strings.ToLower(name)]
+	return entry, ok
+}
+
+func (r *profileRegistry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for _, entry := range r.entries {
+		names = append(names, strings.ToLower(entry.profile.Name()))
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,106 @@
+package aslanwords_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles_should_include_the_builtin_profiles(t *testing.T) {
+	assert.Subset(t, aslanwords.Profiles(), []string{"aslan", "vargr", "vilani", "zhodani"})
+}
+
+func TestWithProfile_when_aslan_is_used_it_should_generate_the_same_words_as_the_default(t *testing.T) {
+	ctx := context.Background()
+
+	for seed := range uint64(20) {
+		expectedWord := aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed))
+
+		assert.Equal(t, expectedWord, aslanwords.MustGenerate(ctx, aslanwords.WithSeed(seed), aslanwords.WithProfile("Aslan")))
+	}
+}
+
+func TestWithProfile_builtin_profiles_should_only_generate_words_with_their_letters(t *testing.T) {
+	for _, name := range []string{"vargr", "vilani", "zhodani"} {
+		t.Run(name, func(t *testing.T) {
+			profile, ok := aslanwords.LookupProfile(name)
+			require.True(t, ok)
+			letters := lettersOf(profile.Phonology())
+			gen, err := aslanwords.New(aslanwords.WithProfile(name))
+			require.NoError(t, err)
+
+			for range 200 {
+				word := mustGenerate(t, gen)
+				assert.Empty(t, strings.Trim(word, letters), "word %s has letters out of the profile", word)
+			}
+		})
+	}
+}
+
+func TestWithProfile_when_the_profile_is_unknown_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.New(aslanwords.WithProfile("droyne"))
+
+	assert.ErrorIs(t, err, aslanwords.ErrUnknownProfile)
+}
+
+func TestWithProfile_constraints_should_be_checked_against_the_profile(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := aslanwords.Generate(ctx, aslanwords.WithPrefix("zh"))
+	require.ErrorIs(t, err, aslanwords.ErrUnsatisfiable)
+
+	word, err := aslanwords.Generate(ctx, aslanwords.WithProfile("zhodani"), aslanwords.WithPrefix("zh"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(word, "zh"))
+}
+
+func TestRegisterProfile_should_make_a_homebrew_profile_available(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "b", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "o", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "p", Weight: 1}},
+		SyllableWeights: map[string]int{"CV": 1},
+	}
+	require.NoError(t, aslanwords.RegisterProfile(aslanwords.NewProfile("Bopo", phonology)))
+
+	word, err := aslanwords.Generate(context.Background(), aslanwords.WithProfile("bopo"), aslanwords.WithNumberOfSyllables(3))
+
+	require.NoError(t, err)
+	assert.Equal(t, "bobobo", word)
+	assert.Contains(t, aslanwords.Profiles(), "bopo")
+}
+
+func TestRegisterProfile_errors(t *testing.T) {
+	invalidPhonology := aslanwords.AslanPhonology()
+	invalidPhonology.Vowels = nil
+
+	testCases := map[string]struct {
+		profile       aslanwords.Profile
+		expectedError string
+	}{
+		"without profile":        {nil, "profile must have a name"},
+		"without name":           {aslanwords.NewProfile(" ", aslanwords.AslanPhonology()), "profile must have a name"},
+		"already registered":     {aslanwords.NewProfile("Vargr", aslanwords.AslanPhonology()), "profile Vargr is already registered"},
+		"with invalid phonology": {aslanwords.NewProfile("broken", invalidPhonology), "profile broken: invalid phonology"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, aslanwords.RegisterProfile(tc.profile), tc.expectedError)
+		})
+	}
+}
+
+func lettersOf(phonology aslanwords.Phonology) string {
+	var letters strings.Builder
+	for _, phonemes := range [][]aslanwords.Phoneme{phonology.FirstConsonants, phonology.Vowels, phonology.LastConsonants} {
+		for _, phoneme := range phonemes {
+			letters.WriteString(phoneme.Text)
+		}
+	}
+	return letters.String()
+}
@@ -0,0 +1,247 @@
+package aslanwords
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// Segmentation is one of the ways a word can be split into syllables
+type Segmentation struct {
+	Word
+	// Weight is the chance of the generator building these syllables once the number of syllables has been chosen
+	Weight float64
+}
+
+// String returns the syllables of the word separated by hyphens along with its weight, like "hkiyr-er-ao (0.0012)"
+func (s Segmentation) String() string {
+	return weightedString(s.Word, s.Weight)
+}
+
+// Format writes the segmentation like String with the verbs s, v and q, and the word like Word.Format with the rest of verbs
+func (s Segmentation) Format(f fmt.State, verb rune) {
+	formatWeighted(f, verb, s.Word, s.Weight)
+}
+
+// Segment returns the ways the word can be split into syllables following the rules of the Aslan language,
+// from the most to the least likely to be generated. The letters of the word are lowercased.
+// Long words can be split in so many ways that only the 1024 most likely ones are returned, which are every way for most words.
+// Splits where the vowel of a syllable has been collapsed with the previous one are only returned if there is no other way to split the word.
+// If the word cannot be split it returns the same error as Validate.
+func Segment(word string) ([]Segmentation, error) {
+	// parses that only differ in a collapsed letter are written the same way, so they are the same segmentation
+	var segmentations []Segmentation
+	indexBySyllables := make(map[string]int)
+	aslanPhonotactics.preferredChart(strings.ToLower(word)).Rank(func(parse parse, weight float64) bool {
+		word := parse.Word()
+		id := strings.Join(word.Keys(), "-") + "/" + strings.Join(word.Syllables(), "-")
+		if i, found := indexBySyllables[id]; found {
+			segmentations[i].Weight += weight
+			return true
+		}
+		indexBySyllables[id] = len(segmentations)
+		segmentations = append(segmentations, Segmentation{Word: word, Weight: weight})
+		return len(segmentations) < maxSegmentations
+	})
+	if len(segmentations) == 0 {
+		return nil, Validate(word)
+	}
+	slices.SortStableFunc(segmentations, func(a, b Segmentation) int {
+		return cmp.Compare(b.Weight, a.Weight)
+	})
+	return segmentations, nil
+}
+
+// maxSegmentations is the number of splits of a word Segment returns. Long words made of vowels can be split in
+// so many ways that returning all of them would take too long.
+const maxSegmentations = 1024
+
+// Split returns the lowercase word split into its most likely syllables, see Segment, without syllables if it breaks the rules
+func (p *phonotactics) Split(word string) Word {
+	return p.bestSplit(word, p.preferredChart(word), 0)
+}
+
+// bestSplit returns the word split into its most likely syllables found in the chart, only among the splits into the given
+// number of syllables unless it is 0
+func (p *phonotactics) bestSplit(word string, chart *parseChart, numberOfSyllables int) Word {
+	split := Word{text: word}
+	chart.Rank(func(found parse, _ float64) bool {
+		if numberOfSyllables > 0 && len(found) != numberOfSyllables {
+			return true
+		}
+		split = found.Word()
+		return false
+	})
+	return split
+}
+
+// parsedSyllable is a syllable found in a word along with the letters of its slots as they were before collapsing the repeated letters
+type parsedSyllable struct {
+	shape   syllable.Shape
+	options []string
+	written []string
+}
+
+func (s parsedSyllable) startsWithVowel() bool {
+	return s.shape.Slots[0] == syllable.VowelSlot
+}
+
+func (s parsedSyllable) endsWithVowel() bool {
+	return s.shape.Slots[len(s.shape.Slots)-1] == syllable.VowelSlot
+}
+
+func (s parsedSyllable) vowel() string {
+	for i, kind := range s.shape.Slots {
+		if kind == syllable.VowelSlot {
+			return s.options[i]
+		}
+	}
+	return ""
+}
+
+// hasVowel tells whether the vowel of the syllable has been written in the word
+func (s parsedSyllable) hasVowel() bool {
+	for i, kind := range s.shape.Slots {
+		if kind == syllable.VowelSlot {
+			return s.written[i] != ""
+		}
+	}
+	return false
+}
+
+func (s parsedSyllable) length() int {
+	length := 0
+	for _, written := range s.written {
+		length += len(written)
+	}
+	return length
+}
+
+func (s parsedSyllable) Syllable() Syllable {
+	parsed := Syllable{Key: s.shape.Key}
+	for i, kind := range s.shape.Slots {
+		switch kind {
+		case syllable.FirstConsonantSlot:
+			parsed.FirstConsonant = s.written[i]
+		case syllable.VowelSlot:
+			parsed.Vowel = s.written[i]
+		case syllable.LastConsonantSlot:
+			parsed.LastConsonant = s.written[i]
+		}
+	}
+	return parsed
+}
+
+type parse []parsedSyllable
+
+// Word returns the word split into the syllables of the parse. Like in a generated word, a syllable whose letters are all
+// collapsed with the previous one is merged into it.
+func (p parse) Word() Word {
+	var word Word
+	for _, s := range p {
+		if s.length() == 0 && len(word.syllables) > 0 {
+			continue
+		}
+		word.syllables = append(word.syllables, s.Syllable())
+		word.text += s.Syllable().String()
+	}
+	return word
+}
+
+// syllablesAt returns the syllables of the given shape the word can have at the position
+func (p *phonotactics) syllablesAt(word string, position int, shape syllable.Shape) []parsedSyllable {
+	found := []parsedSyllable{{shape: shape}}
+	for _, kind := range shape.Slots {
+		var next []parsedSyllable
+		for _, partial := range found {
+			slotPosition := position + partial.length()
+			for _, option := range p.sortedSlotOptions(kind) {
+				written, ok := writtenAt(word, slotPosition, option)
+				if !ok {
+					continue
+				}
+				next = append(next, parsedSyllable{
+					shape:   shape,
+					options: append(slices.Clone(partial.options), option),
+					written: append(slices.Clone(partial.written), written),
+				})
+			}
+		}
+		found = next
+	}
+	return found
+}
+
+// writtenAt returns how the option is written if the word has it at the position, see collapsedOption
+func writtenAt(word string, position int, option string) (string, bool) {
+	written := collapsedOption(word, position, option)
+	if strings.HasPrefix(word[position:], written) {
+		return written, true
+	}
+	return "", false
+}
+
+// collapsedOption returns the letters left of the option at the position of the word once the collapse of repeated
+// letters is applied. The first letters of an option repeating the letter just before it are removed once the letter
+// is repeated too many times, see collapses, so a short option may even vanish.
+func collapsedOption(word string, position int, option string) string {
+	if position == 0 || option[0] != word[position-1] {
+		return option
+	}
+	letter := option[0]
+	limit := 2
+	if collapses(letter) {
+		limit = 1
+	}
+	run := 0
+	for i := position - 1; i >= 0 && word[i] == letter; i-- {
+		run++
+	}
+	repeated := 0
+	for repeated < len(option) && option[repeated] == letter {
+		repeated++
+	}
+	kept := max(0, min(limit, run+repeated)-run)
+	return strings.Repeat(string(letter), kept) + option[repeated:]
+}
+
+func (p *phonotactics) repeatsSingleVowel(previous, next parsedSyllable) bool {
+	return p.repeatsSingleVowelAfter(previous.shape, previous.vowel(), next)
+}
+
+// repeatsSingleVowelAfter tells whether the next syllable repeats the single vowel of a previous one with the given shape and vowel
+func (p *phonotactics) repeatsSingleVowelAfter(previousShape syllable.Shape, previousVowel string, next parsedSyllable) bool {
+	if !endsWithVowel(previousShape) || !next.startsWithVowel() {
+		return false
+	}
+	return p.singleVowels[next.vowel()] && previousVowel == next.vowel()
+}
+
+// Weight returns the chance of generating the syllables of the parse once the number of syllables has been chosen
+func (p *phonotactics) Weight(syllables parse) float64 {
+	weight := 1.0
+	var vowelChain []string
+	for i, s := range syllables {
+		previousKey := ""
+		if i > 0 {
+			previousKey = syllables[i-1].shape.Key
+		}
+		weight *= p.shapeChance(previousKey, s.shape)
+		for j, kind := range s.shape.Slots {
+			if kind != syllable.VowelSlot {
+				weight *= p.slotOptionsAt(kind, placeOf(i, len(syllables))).chance(s.options[j])
+			}
+		}
+
+		if i > 0 && syllables[i-1].endsWithVowel() && s.startsWithVowel() {
+			vowelChain = append(vowelChain, s.vowel())
+			continue
+		}
+		weight *= p.vowelChainChance(vowelChain)
+		vowelChain = []string{s.vowel()}
+	}
+	return weight * p.vowelChainChance(vowelChain)
+}
@@ -0,0 +1,122 @@
+package aslanwords_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegment_should_return_every_split_from_the_most_to_the_least_likely(t *testing.T) {
+	segmentations, err := aslanwords.Segment("Hkiyrerao")
+	require.NoError(t, err)
+
+	require.NotEmpty(t, segmentations)
+	assert.Equal(t, []string{"hkiyr", "er", "ao"}, segmentations[0].Syllables())
+	assert.Equal(t, []string{"CVC", "VC", "V"}, segmentations[0].Keys())
+	for i := 1; i < len(segmentations); i++ {
+		assert.GreaterOrEqual(t, segmentations[i-1].Weight, segmentations[i].Weight)
+	}
+}
+
+func TestSegment_should_not_return_the_same_split_twice(t *testing.T) {
+	segmentations, err := aslanwords.Segment("Hkiyrerao")
+	require.NoError(t, err)
+
+	splits := make(map[string]struct{})
+	for _, segmentation := range segmentations {
+		splits[strings.Join(segmentation.Keys(), "-")+strings.Join(segmentation.Syllables(), "-")] = struct{}{}
+	}
+	assert.Len(t, splits, len(segmentations))
+}
+
+func TestSegment_every_split_should_follow_the_syllable_rules(t *testing.T) {
+	segmentations, err := aslanwords.Segment("aoa")
+	require.NoError(t, err)
+
+	var splits [][]string
+	for _, segmentation := range segmentations {
+		assert.Equal(t, "aoa", segmentation.Word.String())
+		assert.Positive(t, segmentation.Weight)
+		splits = append(splits, segmentation.Syllables())
+	}
+	assert.ElementsMatch(t, [][]string{{"ao", "a"}, {"a", "oa"}, {"a", "o", "a"}}, splits)
+}
+
+func TestSegment_should_split_generated_words_into_the_generated_syllables(t *testing.T) {
+	for seed := range uint64(200) {
+		word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+		if slices.ContainsFunc(word.Parts(), func(s aslanwords.Syllable) bool { return s.Vowel == "" }) {
+			// the vowel of a syllable has been collapsed with the previous one, so the generated split is not returned
+			continue
+		}
+
+		segmentations, err := aslanwords.Segment(word.String())
+		require.NoError(t, err)
+
+		var generatedSplitFound bool
+		for _, segmentation := range segmentations {
+			if assert.ObjectsAreEqual(word.Parts(), segmentation.Parts()) {
+				generatedSplitFound = true
+			}
+		}
+		assert.True(t, generatedSplitFound, "generated split %v of %s not found", word.Syllables(), word)
+	}
+}
+
+func TestSegment_should_not_take_long_to_split_long_words(t *testing.T) {
+	gen, err := aslanwords.New(aslanwords.WithNumberOfSyllables(7), aslanwords.WithSeed(1))
+	require.NoError(t, err)
+	words := []string{"eaoaiaoaeaoaiaoaeaoa"}
+	for range 20 {
+		words = append(words, mustGenerate(t, gen))
+	}
+
+	for _, word := range words {
+		start := time.Now()
+		segmentations, err := aslanwords.Segment(word)
+		elapsed := time.Since(start)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, segmentations)
+		assert.Less(t, elapsed, time.Second, word)
+	}
+}
+
+func TestSegment_when_the_word_can_be_split_in_too_many_ways_it_should_return_the_most_likely_ones(t *testing.T) {
+	word := strings.Repeat("ao", 10)
+
+	segmentations, err := aslanwords.Segment(word)
+
+	require.NoError(t, err)
+	require.Len(t, segmentations, 1024)
+	// splits starting with the single vowel "a" come first alphabetically but they are less likely
+	assert.Equal(t, slices.Repeat([]string{"ao"}, 10), segmentations[0].Syllables())
+	for i := 1; i < len(segmentations); i++ {
+		assert.GreaterOrEqual(t, segmentations[i-1].Weight, segmentations[i].Weight)
+	}
+}
+
+func TestSegment_when_the_word_is_not_valid_it_should_return_the_validation_error(t *testing.T) {
+	_, err := aslanwords.Segment("tkao")
+
+	var validationErr *aslanwords.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestSegmentation_when_printed_it_should_show_the_syllables_and_the_weight(t *testing.T) {
+	segmentations, err := aslanwords.Segment("Hkiyrerao")
+	require.NoError(t, err)
+
+	best := segmentations[0]
+	assert.Equal(t, fmt.Sprintf("hkiyr-er-ao (%g)", best.Weight), fmt.Sprint(best))
+	assert.Equal(t, fmt.Sprintf("hkiyr-er-ao (%g)", best.Weight), best.String())
+	assert.Equal(t, "HKIYRERAO", fmt.Sprintf("%S", best))
+}
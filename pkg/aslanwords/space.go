@@ -0,0 +1,553 @@
+package aslanwords
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// Space is the set of different words the generator can produce with some options
+type Space struct {
+	// Size is the exact number of different words
+	Size *big.Int
+	// Entropy is the number of bits of entropy of picking one of the words with the same chance, that is log2(Size).
+	// It is an upper bound of the Shannon entropy of the generator: generated words are not equally likely, so the entropy
+	// of the generator is lower. See Probability for the chance of a word.
+	Entropy float64
+}
+
+// SpaceSize counts the different words the generator can produce with the given options.
+// Words that can be split into syllables in several ways are only counted once.
+// Constraints like WithPrefix are not taken into account.
+// Words are walked letter by letter, so it takes longer the more syllables are allowed: around a second for five syllables
+// and minutes for eight. It returns the context error if the context is done before the words are counted.
+func SpaceSize(ctx context.Context, opts ...GeneratorOption) (Space, error) {
+	options := newGeneratorOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	if err := options.Validate(); err != nil {
+		return Space{}, fmt.Errorf("invalid options: %w", err)
+	}
+
+	if err := options.phonotactics.checkCountable(); err != nil {
+		return Space{}, err
+	}
+	numberOfSyllables := slices.Collect(maps.Keys(options.numberOfSyllablesOpts.Chances()))
+	size, err := newSpaceCounter(options.phonotactics, numberOfSyllables).count(ctx)
+	if err != nil {
+		return Space{}, err
+	}
+	return Space{Size: size, Entropy: log2(size)}, nil
+}
+
+// log2 returns the base 2 logarithm of a number that may not fit in a float64
+func log2(n *big.Int) float64 {
+	if n.Sign() <= 0 {
+		return math.Inf(-1)
+	}
+	mantissa := new(big.Float).SetInt(n)
+	exponent := mantissa.MantExp(mantissa)
+	m, _ := mantissa.Float64()
+	return math.Log2(m) + float64(exponent)
+}
+
+// spaceCounter counts the different words that can be generated by walking them letter by letter.
+// Every way of generating the letters written so far is a generationState, the same letters can be reached by many of them.
+// Words reaching the same set of states can be completed in the same ways, so they are counted together.
+type spaceCounter struct {
+	p                 *phonotactics
+	numberOfSyllables map[int]bool
+	maxSyllables      int
+	tries             map[placedSlot]*optionTrie
+	allTables         tableSet
+	reverseTable      []int
+	letters           []byte
+	settled           map[generationState][]generationState
+	canonicalSlots    [][]canonicalSlot
+	chances           map[optionChanceKey][]float64
+}
+
+// optionChanceKey is an option of a slot along with what its chance depends on, see spaceCounter.optionChances
+type optionChanceKey struct {
+	kind   syllable.SlotKind
+	at     place
+	chain  int
+	option string
+}
+
+// canonicalSlot is the first shape and slot with the same future as another shape and slot: the same slots left to be
+// generated and the same shapes following it. Mapping states to them lets ways of generating a word that only differ in
+// where a syllable started be merged.
+type canonicalSlot struct {
+	shape int8
+	slot  int8
+}
+
+// placedSlot is a kind of slot in a syllable at a place of the word, which changes its consonants when the phonology has positions
+type placedSlot struct {
+	kind syllable.SlotKind
+	at   place
+}
+
+// tableSet holds one bit for each vowel table: the whole table and every vowel swap
+type tableSet uint64
+
+// maxSingleVowels is the number of single vowels whose vowel swaps fit in a tableSet along with the whole table
+const maxSingleVowels = 31
+
+// generationState is a point in the generation of a word: the letters of the option of a slot written so far,
+// or the end of a syllable when slot is past its last slot
+type generationState struct {
+	syllables int8 // number of syllables started
+	shape     int8 // index of the shape of the current syllable, -1 before the first one
+	slot      int8
+	node      int16    // node of the trie of the options of the slot
+	tables    tableSet // vowel tables the syllable may be using, the first bit is the whole table and the rest the vowel swaps
+	last      byte     // last letter generated before collapsing
+	run       int8     // number of times the last letter has been repeated
+	final     bool     // the syllable is the last one of the word, only known when the phonology has positions
+}
+
+// checkCountable returns an error if the words of the phonology cannot be walked letter by letter
+func (p *phonotactics) checkCountable() error {
+	if len(p.singleVowels) > maxSingleVowels {
+		return fmt.Errorf("words of a phonology with more than %d single vowels cannot be counted or enumerated", maxSingleVowels)
+	}
+	return nil
+}
+
+func newSpaceCounter(p *phonotactics, numberOfSyllables []int) *spaceCounter {
+	vowelTables := []weights{p.vowels}
+	for _, swap := range p.vowelSwaps {
+		vowelTables = append(vowelTables, swap.vowels)
+	}
+	c := &spaceCounter{
+		p:                 p,
+		numberOfSyllables: make(map[int]bool),
+		tries: map[placedSlot]*optionTrie{
+			{kind: syllable.FirstConsonantSlot}: newOptionTrie([]weights{p.firstConsonants}),
+			{kind: syllable.VowelSlot}:          newOptionTrie(vowelTables),
+			{kind: syllable.LastConsonantSlot}:  newOptionTrie([]weights{p.lastConsonants}),
+		},
+		allTables: 1<<len(vowelTables) - 1,
+		settled:   make(map[generationState][]generationState),
+		chances:   make(map[optionChanceKey][]float64),
+	}
+	if p.positional {
+		for _, at := range places {
+			for _, kind := range []syllable.SlotKind{syllable.FirstConsonantSlot, syllable.LastConsonantSlot} {
+				c.tries[placedSlot{kind: kind, at: at}] = newOptionTrie([]weights{p.slotOptionsAt(kind, at)})
+			}
+		}
+	}
+	for _, n := range numberOfSyllables {
+		c.numberOfSyllables[n] = true
+		c.maxSyllables = max(c.maxSyllables, n)
+	}
+	// the vowels of the syllable after a swap are the vowels of its reverse swap
+	c.reverseTable = make([]int, len(vowelTables))
+	for i, swap := range p.vowelSwaps {
+		for j, reverse := range p.vowelSwaps {
+			if maps.Equal(swap.nextVowels, reverse.vowels) && maps.Equal(swap.vowels, reverse.nextVowels) {
+				c.reverseTable[i+1] = j + 1
+			}
+		}
+	}
+	letters := make(map[byte]bool)
+	for _, options := range []weights{p.firstConsonants, p.vowels, p.lastConsonants} {
+		for option := range options {
+			for i := range len(option) {
+				letters[option[i]] = true
+			}
+		}
+	}
+	c.letters = slices.Sorted(maps.Keys(letters))
+	c.canonicalSlots = c.newCanonicalSlots()
+	return c
+}
+
+func (c *spaceCounter) newCanonicalSlots() [][]canonicalSlot {
+	shapes := c.p.shapes
+	sameFollowers := func(a, b syllable.Shape) bool {
+		for _, next := range shapes {
+			if c.p.rules.CanBeFollowedBy(a.Key, next.Key) != c.p.rules.CanBeFollowedBy(b.Key, next.Key) {
+				return false
+			}
+		}
+		return endsWithVowel(a) == endsWithVowel(b)
+	}
+	canonical := make([][]canonicalSlot, len(shapes))
+	for i, shape := range shapes {
+		canonical[i] = make([]canonicalSlot, len(shape.Slots)+1)
+		for slot := range canonical[i] {
+			canonical[i][slot] = canonicalSlot{shape: int8(i), slot: int8(slot)}
+			left := shape.Slots[slot:]
+			for j, other := range shapes[:i] {
+				otherSlot := len(other.Slots) - len(left)
+				if otherSlot >= 0 && slices.Equal(other.Slots[otherSlot:], left) && sameFollowers(shape, other) {
+					canonical[i][slot] = canonicalSlot{shape: int8(j), slot: int8(otherSlot)}
+					break
+				}
+			}
+		}
+	}
+	return canonical
+}
+
+// canonical returns the state with the canonical shape and slot of the given one
+func (c *spaceCounter) canonical(st generationState) generationState {
+	if st.shape < 0 {
+		return st
+	}
+	canonical := c.canonicalSlots[st.shape][st.slot]
+	st.shape, st.slot = canonical.shape, canonical.slot
+	return st
+}
+
+// stateSet is a set of generation states along with whether the letters written so far are a complete word
+type stateSet struct {
+	states     []generationState
+	isComplete bool
+}
+
+func (s stateSet) key() string {
+	key := make([]byte, 0, 1+len(s.states)*16)
+	if s.isComplete {
+		key = append(key, 1)
+	}
+	for _, st := range s.states {
+		key = append(key, byte(st.syllables), byte(st.shape), byte(st.slot), byte(st.node>>8), byte(st.node))
+		key = binary.BigEndian.AppendUint64(key, uint64(st.tables))
+		key = append(key, st.last, byte(st.run), flag(st.final))
+	}
+	return string(key)
+}
+
+// count returns the number of different words, or the context error if the context is done before they are all counted
+func (c *spaceCounter) count(ctx context.Context) (*big.Int, error) {
+	type group struct {
+		set   stateSet
+		count *big.Int
+	}
+	total := new(big.Int)
+	initial := c.start()
+	frontier := map[string]*group{initial.key(): {set: initial, count: big.NewInt(1)}}
+	for len(frontier) > 0 {
+		next := make(map[string]*group)
+		for _, g := range frontier {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if g.set.isComplete {
+				total.Add(total, g.count)
+			}
+			for _, letter := range c.letters {
+				set, ok := c.write(g.set, letter)
+				if !ok {
+					continue
+				}
+				key := set.key()
+				if existing, ok := next[key]; ok {
+					existing.count.Add(existing.count, g.count)
+					continue
+				}
+				next[key] = &group{set: set, count: new(big.Int).Set(g.count)}
+			}
+		}
+		frontier = next
+	}
+	return total, nil
+}
+
+// start returns the states before writing any letter
+func (c *spaceCounter) start() stateSet {
+	return c.newStateSet(c.settle(generationState{shape: -1}))
+}
+
+// write returns the states reached by writing the letter after the letters of the set, if any
+func (c *spaceCounter) write(set stateSet, letter byte) (stateSet, bool) {
+	var reached []generationState
+	for _, st := range set.states {
+		reached = append(reached, c.advance(st, letter)...)
+	}
+	if len(reached) == 0 {
+		return stateSet{}, false
+	}
+	return c.newStateSet(reached), true
+}
+
+// newStateSet merges the states that only differ in their vowel tables and removes the ends of syllables,
+// which mean the word is complete if they end it
+func (c *spaceCounter) newStateSet(states []generationState) stateSet {
+	var set stateSet
+	tables := make(map[generationState]tableSet)
+	for _, st := range states {
+		if c.isEndOfSyllable(st) {
+			set.isComplete = set.isComplete || c.endsWord(st)
+			continue
+		}
+		withoutTables := st
+		withoutTables.tables = 0
+		if _, ok := tables[withoutTables]; !ok {
+			set.states = append(set.states, withoutTables)
+		}
+		tables[withoutTables] |= st.tables
+	}
+	for i := range set.states {
+		set.states[i].tables = tables[set.states[i]]
+	}
+	slices.SortFunc(set.states, compareStates)
+	return set
+}
+
+func compareStates(a, b generationState) int {
+	return cmp.Or(
+		cmp.Compare(a.syllables, b.syllables),
+		cmp.Compare(a.shape, b.shape),
+		cmp.Compare(a.slot, b.slot),
+		cmp.Compare(a.node, b.node),
+		cmp.Compare(a.tables, b.tables),
+		cmp.Compare(a.last, b.last),
+		cmp.Compare(a.run, b.run),
+		cmp.Compare(flag(a.final), flag(b.final)),
+	)
+}
+
+func flag(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// endsWord tells whether the end of the syllable of the state can be the end of the word
+func (c *spaceCounter) endsWord(st generationState) bool {
+	return c.numberOfSyllables[int(st.syllables)] && (st.final || !c.p.positional)
+}
+
+func (c *spaceCounter) isEndOfSyllable(st generationState) bool {
+	return st.shape < 0 || int(st.slot) == len(c.p.shapes[st.shape].Slots)
+}
+
+// advance returns the states reached by writing the letter from the given state
+func (c *spaceCounter) advance(st generationState, letter byte) []generationState {
+	if c.isEndOfSyllable(st) || c.isCollapsed(st, letter) {
+		return nil
+	}
+	next, ok := c.generate(st, letter)
+	if !ok {
+		return nil
+	}
+	return c.settle(next)
+}
+
+// generate moves the state to the child node reached by generating the letter, if any of its vowel tables has an option with it
+func (c *spaceCounter) generate(st generationState, letter byte) (generationState, bool) {
+	child, ok := c.trie(st).nodes[st.node].children[letter]
+	if !ok {
+		return st, false
+	}
+	if c.isVowelSlot(st) {
+		st.tables &= child.tables
+		if st.tables == 0 {
+			return st, false
+		}
+	}
+	if letter == st.last {
+		st.run++
+	} else {
+		st.last, st.run = letter, 0
+	}
+	st.node = child.id
+	return st, true
+}
+
+// settle returns the states the given one can reach without writing any letter: those waiting for a letter to be written
+// and the ends of syllables, finishing the options of the slots and picking the shapes of the syllables as needed.
+func (c *spaceCounter) settle(st generationState) []generationState {
+	st = c.canonical(st)
+	if states, ok := c.settled[st]; ok {
+		return states
+	}
+	states := c.settleUncached(st)
+	c.settled[st] = states
+	return states
+}
+
+func (c *spaceCounter) settleUncached(st generationState) []generationState {
+	if c.isEndOfSyllable(st) {
+		states := []generationState{st}
+		for _, next := range c.nextSyllables(st) {
+			states = append(states, c.settle(next)...)
+		}
+		return states
+	}
+
+	node := c.trie(st).nodes[st.node]
+	var states []generationState
+	if len(node.children) > 0 {
+		states = append(states, st)
+	}
+	for letter := range node.children {
+		if !c.isCollapsed(st, letter) {
+			continue
+		}
+		if next, ok := c.generate(st, letter); ok {
+			states = append(states, c.settle(next)...)
+		}
+	}
+	finished := st
+	if c.isVowelSlot(st) {
+		finished.tables &= node.optionTables
+	}
+	if node.optionTables != 0 && finished.tables != 0 {
+		finished.slot++
+		finished.node = 0
+		// once the vowel is written the tables only matter to the next syllable if this one ends with it
+		if c.isVowelSlot(st) && !c.isEndOfSyllable(finished) {
+			finished.tables = 1
+		}
+		states = append(states, c.settle(finished)...)
+	}
+	return states
+}
+
+// isCollapsed tells whether the letter would be removed by the collapse of repeated letters if it was generated now
+func (c *spaceCounter) isCollapsed(st generationState, letter byte) bool {
+	if letter != st.last {
+		return false
+	}
+	limit := int8(2)
+	if collapses(letter) {
+		limit = 1
+	}
+	return st.run+1 >= limit
+}
+
+// nextSyllables returns the starts of the syllables that can follow the ended one along with the vowel tables they may use
+func (c *spaceCounter) nextSyllables(ended generationState) []generationState {
+	if int(ended.syllables) >= c.maxSyllables || ended.final {
+		return nil
+	}
+	var starts []generationState
+	for i, shape := range c.p.shapes {
+		if shape.Weight == 0 {
+			continue
+		}
+		start := ended
+		start.syllables++
+		start.shape, start.slot, start.node = int8(i), 0, 0
+		// only a syllable ending with vowel may start a chain of vowels using a swap, the rest use the whole table
+		start.tables = 1
+		if endsWithVowel(shape) {
+			start.tables = c.allTables
+		}
+		if ended.shape >= 0 {
+			previous := c.p.shapes[ended.shape]
+			if !c.p.rules.CanBeFollowedBy(previous.Key, shape.Key) {
+				continue
+			}
+			if endsWithVowel(previous) && shape.Slots[0] == syllable.VowelSlot {
+				start.tables = c.chainedTables(ended.tables)
+			}
+		}
+		if start.tables != 0 {
+			starts = append(starts, c.placed(start)...)
+		}
+	}
+	return starts
+}
+
+// placed returns the start of the syllable as one followed by other syllables and as the last one of the word, as far as
+// the number of syllables allows it. Without positions the consonants are the same anywhere, so the start is kept as it is.
+func (c *spaceCounter) placed(start generationState) []generationState {
+	if !c.p.positional {
+		return []generationState{start}
+	}
+	var placed []generationState
+	if int(start.syllables) < c.maxSyllables {
+		placed = append(placed, start)
+	}
+	if c.numberOfSyllables[int(start.syllables)] {
+		start.final = true
+		placed = append(placed, start)
+	}
+	return placed
+}
+
+// chainedTables returns the vowel tables of a syllable whose vowel follows the vowel of a syllable using the given tables
+func (c *spaceCounter) chainedTables(previous tableSet) tableSet {
+	if c.allTables == 1 {
+		return 1
+	}
+	var tables tableSet
+	for t := 1; t < len(c.reverseTable); t++ {
+		if previous&(1<<t) != 0 {
+			tables |= 1 << c.reverseTable[t]
+		}
+	}
+	return tables
+}
+
+// trie returns the options of the slot of the state
+func (c *spaceCounter) trie(st generationState) *optionTrie {
+	slot := placedSlot{kind: c.p.shapes[st.shape].Slots[st.slot]}
+	if c.p.positional && slot.kind != syllable.VowelSlot {
+		slot.at = place{first: st.syllables == 1, last: st.final}
+	}
+	return c.tries[slot]
+}
+
+func (c *spaceCounter) isVowelSlot(st generationState) bool {
+	return c.p.shapes[st.shape].Slots[st.slot] == syllable.VowelSlot
+}
+
+func endsWithVowel(shape syllable.Shape) bool {
+	return shape.Slots[len(shape.Slots)-1] == syllable.VowelSlot
+}
+
+// optionTrie holds the options of a slot in every table merging their common beginnings, so options starting with
+// the same letters are walked at once. Each node knows the tables with options going through it and the tables where
+// it is a whole option. The root keeps every node by its id.
+type optionTrie struct {
+	children     map[byte]*optionTrie
+	letters      []byte // letters of the children in alphabetical order
+	text         string // letters from the root to the node
+	id           int16
+	tables       tableSet
+	optionTables tableSet
+	nodes        []*optionTrie
+}
+
+func newOptionTrie(tables []weights) *optionTrie {
+	root := &optionTrie{children: make(map[byte]*optionTrie)}
+	root.nodes = []*optionTrie{root}
+	for t, options := range tables {
+		for option := range options {
+			node := root
+			node.tables |= 1 << t
+			for i := range len(option) {
+				child, ok := node.children[option[i]]
+				if !ok {
+					child = &optionTrie{children: make(map[byte]*optionTrie), text: option[:i+1], id: int16(len(root.nodes))}
+					root.nodes = append(root.nodes, child)
+					node.children[option[i]] = child
+					node.letters = append(node.letters, option[i])
+					slices.Sort(node.letters)
+				}
+				node = child
+				node.tables |= 1 << t
+			}
+			node.optionTables |= 1 << t
+		}
+	}
+	return root
+}
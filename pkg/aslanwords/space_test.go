@@ -0,0 +1,96 @@
+package aslanwords_test
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpaceSize_should_count_the_different_words_once(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels:          []aslanwords.Phoneme{{Text: "e", Weight: 1}, {Text: "ee", Weight: 1}},
+		LastConsonants:  []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights: map[string]int{"V": 1, "CV": 1},
+	}
+
+	// of the 16 pairs of e, ee, ke and kee, the ones made only of e are all written ee, and ke or kee followed by e or ee as kee
+	space, err := aslanwords.SpaceSize(context.Background(), aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllables(2))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(10), space.Size)
+}
+
+func TestSpaceSize_of_aslan_words(t *testing.T) {
+	testCases := map[string]struct {
+		opts         []aslanwords.GeneratorOption
+		expectedSize int64
+	}{
+		"one syllable":  {[]aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(1)}, 3960},
+		"two syllables": {[]aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllables(2)}, 1768580},
+		// some words are written the same with one and two syllables
+		"one or two syllables":      {[]aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllablesBetween(1, 3)}, 1769680},
+		"range with a single value": {[]aslanwords.GeneratorOption{aslanwords.WithNumberOfSyllablesBetween(2, 2)}, 1768580},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			space, err := aslanwords.SpaceSize(context.Background(), tc.opts...)
+
+			require.NoError(t, err)
+			assert.Equal(t, big.NewInt(tc.expectedSize), space.Size)
+			assert.InDelta(t, math.Log2(float64(tc.expectedSize)), space.Entropy, 1e-9)
+		})
+	}
+}
+
+func TestSpaceSize_when_options_are_invalid_it_should_return_error(t *testing.T) {
+	_, err := aslanwords.SpaceSize(context.Background(), aslanwords.WithNumberOfSyllables(0))
+
+	assert.ErrorContains(t, err, "invalid options")
+}
+
+func TestSpaceSize_when_the_context_is_done_it_should_stop_counting(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := aslanwords.SpaceSize(ctx, aslanwords.WithNumberOfSyllables(8))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSpaceSize_with_eight_single_vowels_it_should_count_every_word(t *testing.T) {
+	phonology := aslanwords.Phonology{
+		FirstConsonants: []aslanwords.Phoneme{{Text: "k", Weight: 1}},
+		Vowels: []aslanwords.Phoneme{
+			{Text: "a", Weight: 1}, {Text: "e", Weight: 1}, {Text: "i", Weight: 1}, {Text: "o", Weight: 1},
+			{Text: "u", Weight: 1}, {Text: "y", Weight: 1}, {Text: "w", Weight: 1}, {Text: "x", Weight: 1},
+		},
+		LastConsonants:            []aslanwords.Phoneme{{Text: "r", Weight: 1}},
+		SyllableWeights:           map[string]int{"V": 1},
+		AvoidRepeatedSingleVowels: true,
+	}
+
+	// every vowel but the previous one, like "axe", which is only generated with the swap without "x" and its reverse,
+	// the one with "x" as its only single vowel
+	space, err := aslanwords.SpaceSize(context.Background(), aslanwords.WithPhonology(phonology), aslanwords.WithNumberOfSyllables(3))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(8*7*7), space.Size)
+}
+
+func TestSpaceSize_when_the_phonology_has_too_many_single_vowels_it_should_return_error(t *testing.T) {
+	phonology := aslanwords.AslanPhonology()
+	for _, letter := range "bcdfgjlmnpqsvzABCDEFGHIJKLMNOPQRS" {
+		phonology.Vowels = append(phonology.Vowels, aslanwords.Phoneme{Text: string(letter), Weight: 1})
+	}
+
+	_, err := aslanwords.SpaceSize(context.Background(), aslanwords.WithPhonology(phonology))
+
+	assert.ErrorContains(t, err, "more than 31 single vowels")
+}
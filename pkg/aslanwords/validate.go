@@ -0,0 +1,301 @@
+package aslanwords
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule is a rule of the Aslan language a word can break
+type Rule string
+
+const (
+	// RuleEmptyWord is broken by a word without letters
+	RuleEmptyWord Rule = "a word needs at least one syllable"
+	// RuleUnknownLetter is broken by letters that are not used by the Aslan language
+	RuleUnknownLetter Rule = "letter not used by the aslan language"
+	// RuleMissingVowel is broken by a word without vowels
+	RuleMissingVowel Rule = "every syllable needs a vowel"
+	// RuleInvalidFirstConsonant is broken by a word starting with consonants a syllable cannot start with
+	RuleInvalidFirstConsonant Rule = "a syllable cannot start with these consonants"
+	// RuleInvalidLastConsonant is broken by a word ending with consonants a syllable cannot end with
+	RuleInvalidLastConsonant Rule = "a syllable cannot end with these consonants"
+	// RuleInvalidConsonantCluster is broken by consonants between two vowels that are neither the start nor the end of a syllable
+	RuleInvalidConsonantCluster Rule = "these consonants cannot be between two vowels"
+	// RuleConsonantAfterLastConsonant is broken by a syllable ending with consonant followed by a syllable starting with consonant
+	RuleConsonantAfterLastConsonant Rule = "a syllable ending with consonant can only be followed by a syllable starting with vowel"
+	// RuleInvalidVowel is broken by vowels that cannot be split into Aslan vowels
+	RuleInvalidVowel Rule = "these vowels cannot be split into aslan vowels"
+	// RuleConsecutiveSingleVowels is broken by two consecutive syllables with the same single letter vowel
+	RuleConsecutiveSingleVowels Rule = "two consecutive syllables cannot have the same single vowel"
+	// RuleInvalidSyllables is broken by a word that cannot be split into valid syllables for any other reason
+	RuleInvalidSyllables Rule = "the word cannot be split into valid syllables"
+)
+
+// Violation is a broken rule and where it has been broken
+type Violation struct {
+	Rule Rule
+	// Position is the index of the first letter of the fragment in the word, counting letters instead of bytes
+	Position int
+	// Fragment are the letters that break the rule
+	Fragment string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %q at position %d", v.Rule, v.Fragment, v.Position)
+}
+
+// ValidationError is the error returned when a word breaks any rule of the Aslan language
+type ValidationError struct {
+	Word       string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		violations[i] = v.String()
+	}
+	return fmt.Sprintf("%q is not a valid aslan word: %s", e.Word, strings.Join(violations, "; "))
+}
+
+// Validate checks whether the word follows the rules used to generate-word Aslan words, ignoring the case of the letters.
+// As in the generated words, a letter repeated when joining two vowels may have been collapsed.
+// It returns nil for a valid word and a *ValidationError with every violation found otherwise.
+func Validate(word string) error {
+	violations := aslanPhonotactics.Violations(word)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Word: word, Violations: violations}
+}
+
+// letterRun is a sequence of consecutive consonants or consecutive vowels of a word
+type letterRun struct {
+	letters  string
+	position int
+	isVowel  bool
+}
+
+// Violations returns the rules broken by the word.
+// A word is valid when it can be split into syllables, otherwise its runs of consonants and vowels are checked to explain why.
+func (p *phonotactics) Violations(word string) []Violation {
+	letters := []rune(strings.ToLower(word))
+	if len(letters) == 0 {
+		return []Violation{{Rule: RuleEmptyWord}}
+	}
+	if p.chart(string(letters)).CanBeSplit() {
+		return nil
+	}
+
+	var violations []Violation
+	for i, letter := range letters {
+		if !p.consonantLetters[letter] && !p.vowelLetters[letter] {
+			violations = append(violations, Violation{Rule: RuleUnknownLetter, Position: i, Fragment: string(letter)})
+		}
+	}
+	if len(violations) > 0 {
+		return violations
+	}
+
+	runs := p.letterRuns(letters)
+	if len(runs) == 1 && !runs[0].isVowel {
+		return []Violation{{Rule: RuleMissingVowel, Position: 0, Fragment: runs[0].letters}}
+	}
+	for i, run := range runs {
+		isFirst, isLast := i == 0, i == len(runs)-1
+		switch {
+		case run.isVowel:
+			violations = append(violations, p.vowelRunViolations(run)...)
+		case isFirst:
+			if _, ok := p.firstConsonants[run.letters]; !ok {
+				violations = append(violations, Violation{Rule: RuleInvalidFirstConsonant, Position: run.position, Fragment: run.letters})
+			}
+		case isLast:
+			if _, ok := p.lastConsonants[run.letters]; !ok {
+				violations = append(violations, Violation{Rule: RuleInvalidLastConsonant, Position: run.position, Fragment: run.letters})
+			}
+		default:
+			violations = append(violations, p.consonantsBetweenVowelsViolations(run)...)
+		}
+	}
+	if len(violations) == 0 {
+		return []Violation{{Rule: RuleInvalidSyllables, Position: 0, Fragment: word}}
+	}
+	return violations
+}
+
+func (p *phonotactics) letterRuns(letters []rune) []letterRun {
+	var runs []letterRun
+	for i, letter := range letters {
+		isVowel := p.vowelLetters[letter]
+		if len(runs) == 0 || runs[len(runs)-1].isVowel != isVowel {
+			runs = append(runs, letterRun{position: i, isVowel: isVowel})
+		}
+		runs[len(runs)-1].letters += string(letter)
+	}
+	return runs
+}
+
+// consonantsBetweenVowelsViolations checks the consonants between two vowels are the end of a syllable or the start of the next one
+func (p *phonotactics) consonantsBetweenVowelsViolations(run letterRun) []Violation {
+	if _, ok := p.firstConsonants[run.letters]; ok {
+		return nil
+	}
+	if _, ok := p.lastConsonants[run.letters]; ok {
+		return nil
+	}
+	splits := p.consonantSplits(run.letters)
+	if len(splits) == 0 {
+		return []Violation{{Rule: RuleInvalidConsonantCluster, Position: run.position, Fragment: run.letters}}
+	}
+	if p.lastConsonantCanBeFollowedByConsonants {
+		return nil
+	}
+	position := run.position + len([]rune(run.letters[:splits[0]]))
+	return []Violation{{Rule: RuleConsonantAfterLastConsonant, Position: position, Fragment: run.letters}}
+}
+
+// consonantSplits returns the byte indexes where the consonants can be split into the end of a syllable and the start of the next one
+func (p *phonotactics) consonantSplits(consonants string) []int {
+	var splits []int
+	for i := 1; i < len(consonants); i++ {
+		_, isLast := p.lastConsonants[consonants[:i]]
+		_, isFirst := p.firstConsonants[consonants[i:]]
+		if isLast && isFirst {
+			splits = append(splits, i)
+		}
+	}
+	return splits
+}
+
+// vowelRunViolations checks the vowels can be split into the vowels of consecutive syllables
+func (p *phonotactics) vowelRunViolations(run letterRun) []Violation {
+	splitter := newVowelSplitter(p, run.letters)
+	if !splitter.canSplit(0, "", false) {
+		return []Violation{{Rule: RuleInvalidVowel, Position: run.position, Fragment: run.letters}}
+	}
+	if splitter.canSplit(0, "", true) {
+		return nil
+	}
+	split := splitter.firstSplit()
+	repeated := p.repeatedSingleVowel(split)
+	position := run.position
+	for _, v := range split[:repeated-1] {
+		position += len([]rune(v.text))
+	}
+	return []Violation{{Rule: RuleConsecutiveSingleVowels, Position: position, Fragment: split[repeated-1].text + split[repeated].text}}
+}
+
+// splitVowel is a vowel of a syllable and the letters it has left in the word once the repeated letters are collapsed
+type splitVowel struct {
+	vowel string
+	text  string
+}
+
+// vowelSplitter looks for the ways a run of vowels can be split into vowels.
+// When the previous vowel ends with a letter that is collapsed if repeated, the next vowel may have lost its first letter.
+// Long runs can be split in too many ways to list them, so it remembers whether the rest of the run can be split
+// after each vowel instead.
+type vowelSplitter struct {
+	p       *phonotactics
+	letters string
+	splits  map[vowelSplitState]bool
+}
+
+// vowelSplitState is the rest of the run to be split after a vowel
+type vowelSplitState struct {
+	offset        int
+	previousVowel string
+	avoidRepeats  bool
+}
+
+func newVowelSplitter(p *phonotactics, letters string) *vowelSplitter {
+	return &vowelSplitter{p: p, letters: letters, splits: make(map[vowelSplitState]bool)}
+}
+
+// canSplit tells whether the letters from the offset can be split into vowels after the previous one,
+// without repeating a single vowel if avoidRepeats is set
+func (s *vowelSplitter) canSplit(offset int, previousVowel string, avoidRepeats bool) bool {
+	if offset == len(s.letters) {
+		return true
+	}
+	state := vowelSplitState{offset: offset, previousVowel: previousVowel, avoidRepeats: avoidRepeats}
+	if found, ok := s.splits[state]; ok {
+		return found
+	}
+	found := false
+	for i := offset + 1; i <= len(s.letters) && !found; i++ {
+		for _, v := range s.p.vowelsWritten(s.letters[offset:i], previousVowel) {
+			if avoidRepeats && s.p.singleVowels[v] && v == previousVowel {
+				continue
+			}
+			if s.canSplit(i, v, avoidRepeats) {
+				found = true
+				break
+			}
+		}
+	}
+	s.splits[state] = found
+	return found
+}
+
+// firstSplit returns the first way of splitting the letters into vowels, trying the shortest vowels first.
+// It is empty if the letters cannot be split.
+func (s *vowelSplitter) firstSplit() []splitVowel {
+	var split []splitVowel
+	offset, previousVowel := 0, ""
+	for offset < len(s.letters) {
+		next, found := s.nextVowel(offset, previousVowel)
+		if !found {
+			return nil
+		}
+		split = append(split, next)
+		offset, previousVowel = offset+len(next.text), next.vowel
+	}
+	return split
+}
+
+// nextVowel returns the first vowel at the offset after which the rest of the letters can be split
+func (s *vowelSplitter) nextVowel(offset int, previousVowel string) (splitVowel, bool) {
+	for i := offset + 1; i <= len(s.letters); i++ {
+		for _, v := range s.p.vowelsWritten(s.letters[offset:i], previousVowel) {
+			if s.canSplit(i, v, false) {
+				return splitVowel{vowel: v, text: s.letters[offset:i]}, true
+			}
+		}
+	}
+	return splitVowel{}, false
+}
+
+// vowelsWritten returns the vowels that are written as the given text after the previous vowel.
+// A vowel starting with the letter the previous one ends with is collapsed with it, losing its first letter.
+func (p *phonotactics) vowelsWritten(text, previousVowel string) []string {
+	lastLetter := ""
+	if previousVowel != "" {
+		lastLetter = previousVowel[len(previousVowel)-1:]
+	}
+	if lastLetter == "" || !collapses(lastLetter[0]) {
+		if _, ok := p.vowels[text]; ok {
+			return []string{text}
+		}
+		return nil
+	}
+	var vowels []string
+	if _, ok := p.vowels[text]; ok && !strings.HasPrefix(text, lastLetter) {
+		vowels = append(vowels, text)
+	}
+	if _, ok := p.vowels[lastLetter+text]; ok && !strings.HasPrefix(text, lastLetter) {
+		vowels = append(vowels, lastLetter+text)
+	}
+	return vowels
+}
+
+// repeatedSingleVowel returns the index of the first vowel that repeats the single vowel before it, or -1 if there is none
+func (p *phonotactics) repeatedSingleVowel(vowels []splitVowel) int {
+	for i := 1; i < len(vowels); i++ {
+		if p.singleVowels[vowels[i].vowel] && vowels[i].vowel == vowels[i-1].vowel {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,84 @@
+package aslanwords_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_generated_words_should_be_valid(t *testing.T) {
+	gen, err := aslanwords.New(aslanwords.WithSeed(1), aslanwords.WithNumberOfSyllablesBetween(1, 8))
+	require.NoError(t, err)
+
+	for range 2000 {
+		word, err := gen.Generate(context.Background())
+		require.NoError(t, err)
+
+		assert.NoError(t, aslanwords.Validate(word))
+	}
+}
+
+func TestValidate_canon_words_should_be_valid(t *testing.T) {
+	canonWords := []string{"Hkiyrerao", "Ktiyhui", "Kusyu", "Hiroahkoi", "Ealoa"}
+
+	for _, word := range canonWords {
+		t.Run(word, func(t *testing.T) {
+			assert.NoError(t, aslanwords.Validate(word))
+		})
+	}
+}
+
+func TestValidate_when_the_word_breaks_a_rule(t *testing.T) {
+	testCases := map[string]struct {
+		word              string
+		expectedViolation aslanwords.Violation
+	}{
+		"empty word":                     {"", aslanwords.Violation{Rule: aslanwords.RuleEmptyWord}},
+		"letter not used by aslans":      {"hkibe", aslanwords.Violation{Rule: aslanwords.RuleUnknownLetter, Position: 3, Fragment: "b"}},
+		"word without vowels":            {"kht", aslanwords.Violation{Rule: aslanwords.RuleMissingVowel, Position: 0, Fragment: "kht"}},
+		"invalid first consonant":        {"tkao", aslanwords.Violation{Rule: aslanwords.RuleInvalidFirstConsonant, Position: 0, Fragment: "tk"}},
+		"invalid last consonant":         {"hkak", aslanwords.Violation{Rule: aslanwords.RuleInvalidLastConsonant, Position: 3, Fragment: "k"}},
+		"invalid consonants in between":  {"afwo", aslanwords.Violation{Rule: aslanwords.RuleInvalidConsonantCluster, Position: 1, Fragment: "fw"}},
+		"consonant after last consonant": {"tahkta", aslanwords.Violation{Rule: aslanwords.RuleConsonantAfterLastConsonant, Position: 3, Fragment: "hkt"}},
+		"invalid vowels":                 {"hkyy", aslanwords.Violation{Rule: aslanwords.RuleInvalidVowel, Position: 2, Fragment: "yy"}},
+		"collapsed vowels":               {"hkaa", aslanwords.Violation{Rule: aslanwords.RuleInvalidVowel, Position: 2, Fragment: "aa"}},
+		"consecutive single vowels":      {"hkeel", aslanwords.Violation{Rule: aslanwords.RuleConsecutiveSingleVowels, Position: 2, Fragment: "ee"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := aslanwords.Validate(tc.word)
+
+			var validationErr *aslanwords.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.word, validationErr.Word)
+			assert.Equal(t, []aslanwords.Violation{tc.expectedViolation}, validationErr.Violations)
+		})
+	}
+}
+
+func TestValidate_should_report_every_violation(t *testing.T) {
+	err := aslanwords.Validate("tkaafwok")
+
+	var validationErr *aslanwords.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Violations, 4)
+}
+
+func TestValidate_should_not_take_long_to_reject_a_long_run_of_vowels(t *testing.T) {
+	word := strings.Repeat("ai", 50) + "tk"
+
+	start := time.Now()
+	err := aslanwords.Validate(word)
+	elapsed := time.Since(start)
+
+	var validationErr *aslanwords.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []aslanwords.Violation{{Rule: aslanwords.RuleInvalidLastConsonant, Position: 100, Fragment: "tk"}}, validationErr.Violations)
+	assert.Less(t, elapsed, time.Second)
+}
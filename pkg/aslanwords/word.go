@@ -0,0 +1,79 @@
+package aslanwords
+
+import "strings"
+
+// Word is a generated Aslan word along with the syllables it is made of
+type Word struct {
+	text      string
+	syllables []Syllable
+}
+
+// Syllable is one of the syllables of a word with the parts it has been built from.
+// Letters repeated when joining the syllables are collapsed, so the parts of a syllable hold the letters that made it to the word.
+// A syllable whose letters are all collapsed with the previous one, like a vowel "a" after "oa", is merged into it,
+// so a word may have fewer syllables than the ones it was generated with.
+type Syllable struct {
+	// Key is the structure of the syllable: V, CV, VC or CVC
+	Key string
+	// FirstConsonant is the consonant that starts the syllable, empty if it starts with a vowel
+	FirstConsonant string
+	// Vowel is the vowel of the syllable
+	Vowel string
+	// LastConsonant is the consonant that ends the syllable, empty if it ends with a vowel
+	LastConsonant string
+}
+
+// String returns the text of the syllable
+func (s Syllable) String() string {
+	return s.FirstConsonant + s.Vowel + s.LastConsonant
+}
+
+// String returns the text of the word
+func (w Word) String() string {
+	return w.text
+}
+
+// Syllables returns the text of each syllable of the word
+func (w Word) Syllables() []string {
+	syllables := make([]string, len(w.syllables))
+	for i, s := range w.syllables {
+		syllables[i] = s.String()
+	}
+	return syllables
+}
+
+// Keys returns the structure of each syllable of the word where:
+// - `V` is a syllable made of an aslan vowel
+// - `CV` is an aslan consonant followed by an aslan vowel
+// - `VC` is an aslan vowel followed by an aslan consonant
+// - `CVC` is an aslan consonant followed by an aslan vowel and ending with an aslan consonant
+func (w Word) Keys() []string {
+	keys := make([]string, len(w.syllables))
+	for i, s := range w.syllables {
+		keys[i] = s.Key
+	}
+	return keys
+}
+
+// sounds returns the consonants and vowels of the syllables of the word in order, or its letters if it has no syllables
+func (w Word) sounds() []string {
+	if len(w.syllables) == 0 {
+		return strings.Split(w.text, "")
+	}
+	var sounds []string
+	for _, s := range w.syllables {
+		for _, sound := range []string{s.FirstConsonant, s.Vowel, s.LastConsonant} {
+			if sound != "" {
+				sounds = append(sounds, sound)
+			}
+		}
+	}
+	return sounds
+}
+
+// Parts returns the syllables of the word with the consonants and vowel each one is made of
+func (w Word) Parts() []Syllable {
+	parts := make([]Syllable, len(w.syllables))
+	copy(parts, w.syllables)
+	return parts
+}
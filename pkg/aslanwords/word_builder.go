@@ -0,0 +1,55 @@
+package aslanwords
+
+import (
+	"github.com/carloscasalar/aslan-words/internal/syllable"
+)
+
+// wordBuilder joins the generated parts of the syllables collapsing the repeated letters as fantasyname does.
+// Since the collapse goes from left to right, the letters removed when adding a part are always taken from that part.
+// A syllable whose letters are all removed, like a vowel "a" after "oa", is merged into the previous one, where its letter already is.
+type wordBuilder struct {
+	raw       string
+	collapsed string
+	syllables []Syllable
+}
+
+func newWordBuilder() *wordBuilder {
+	return &wordBuilder{}
+}
+
+// StartSyllable adds a new syllable with the given key to the word
+func (b *wordBuilder) StartSyllable(key string) {
+	b.mergeVanished()
+	b.syllables = append(b.syllables, Syllable{Key: key})
+}
+
+// AddSlot adds the generated text of a part to the current syllable
+func (b *wordBuilder) AddSlot(kind syllable.SlotKind, text string) {
+	b.raw += text
+	collapsed := collapse(b.raw)
+	text = collapsed[len(b.collapsed):]
+	b.collapsed = collapsed
+
+	current := &b.syllables[len(b.syllables)-1]
+	switch kind {
+	case syllable.FirstConsonantSlot:
+		current.FirstConsonant = text
+	case syllable.VowelSlot:
+		current.Vowel = text
+	case syllable.LastConsonantSlot:
+		current.LastConsonant = text
+	}
+}
+
+// Word returns the built word
+func (b *wordBuilder) Word() Word {
+	b.mergeVanished()
+	return Word{text: b.collapsed, syllables: b.syllables}
+}
+
+// mergeVanished merges the current syllable into the previous one if all its letters have been collapsed with it
+func (b *wordBuilder) mergeVanished() {
+	if n := len(b.syllables); n > 1 && b.syllables[n-1].String() == "" {
+		b.syllables = b.syllables[:n-1]
+	}
+}
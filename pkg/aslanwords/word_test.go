@@ -0,0 +1,86 @@
+package aslanwords_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWord_should_have_at_most_as_many_syllables_as_requested(t *testing.T) {
+	merged := 0
+	for seed := range uint64(500) {
+		word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithNumberOfSyllables(4), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.LessOrEqual(t, len(word.Syllables()), 4, "seed %d", seed)
+		assert.Len(t, word.Keys(), len(word.Syllables()), "seed %d", seed)
+		assert.Len(t, word.Parts(), len(word.Syllables()), "seed %d", seed)
+		probability, err := aslanwords.Probability(word.String(), aslanwords.WithNumberOfSyllables(4))
+		require.NoError(t, err)
+		assert.Positive(t, probability, "%s cannot be split into 4 syllables", word)
+		if len(word.Syllables()) < 4 {
+			merged++
+		}
+	}
+	// only the words with a syllable whose letters are all collapsed have fewer syllables
+	assert.Less(t, merged, 50)
+}
+
+func TestGenerateWord_syllables_joined_should_be_the_word(t *testing.T) {
+	for seed := range uint64(200) {
+		word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.Equal(t, word.String(), strings.Join(word.Syllables(), ""), "seed %d", seed)
+	}
+}
+
+func TestGenerateWord_should_not_have_empty_syllables(t *testing.T) {
+	for seed := range uint64(2000) {
+		word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		assert.NotContains(t, word.Syllables(), "", "seed %d", seed)
+		assert.Equal(t, word.String(), strings.Join(word.Syllables(), ""), "seed %d", seed)
+		assert.Len(t, word.Keys(), len(word.Syllables()), "seed %d", seed)
+	}
+}
+
+func TestGenerateWord_when_the_letters_of_a_syllable_are_all_collapsed_it_should_be_merged_into_the_previous_one(t *testing.T) {
+	// the vowel "a" of the third syllable is collapsed with the end of "oa"
+	word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithSeed(39))
+	require.NoError(t, err)
+
+	assert.Equal(t, "uioawuaftoaw", word.String())
+	assert.Equal(t, []string{"ui", "oa", "wua", "ftoaw"}, word.Syllables())
+	assert.Equal(t, []string{"V", "V", "CV", "CVC"}, word.Keys())
+}
+
+func TestGenerateWord_parts_should_match_the_syllable_key(t *testing.T) {
+	for seed := range uint64(200) {
+		word, err := aslanwords.GenerateWord(context.Background(), aslanwords.WithSeed(seed))
+		require.NoError(t, err)
+
+		for _, part := range word.Parts() {
+			require.Contains(t, []string{"V", "CV", "VC", "CVC"}, part.Key)
+			if !strings.HasPrefix(part.Key, "C") {
+				assert.Empty(t, part.FirstConsonant, "syllable %s of %s", part.Key, word)
+			}
+			if !strings.HasSuffix(part.Key, "C") {
+				assert.Empty(t, part.LastConsonant, "syllable %s of %s", part.Key, word)
+			}
+		}
+	}
+}
+
+func TestGenerateWord_should_generate_the_same_word_as_generate_with_the_same_seed(t *testing.T) {
+	ctx := context.Background()
+	word, err := aslanwords.GenerateWord(ctx, aslanwords.WithSeed(9))
+	require.NoError(t, err)
+
+	assert.Equal(t, aslanwords.MustGenerate(ctx, aslanwords.WithSeed(9)), word.String())
+}
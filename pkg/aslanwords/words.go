@@ -0,0 +1,32 @@
+package aslanwords
+
+import (
+	"context"
+	"iter"
+)
+
+// Words returns an endless sequence of random Aslan words generated with the given options.
+// The sequence ends when the loop is broken or, once the context is done, after yielding the context error.
+// If the options are invalid the only element of the sequence is the validation error.
+func Words(ctx context.Context, opts ...GeneratorOption) iter.Seq2[Word, error] {
+	gen, err := New(opts...)
+	if err != nil {
+		return func(yield func(Word, error) bool) {
+			yield(Word{}, err)
+		}
+	}
+	return gen.Words(ctx)
+}
+
+// Words returns an endless sequence of random Aslan words.
+// The sequence ends when the loop is broken or, once the context is done, after yielding the context error.
+func (g *Generator) Words(ctx context.Context) iter.Seq2[Word, error] {
+	return func(yield func(Word, error) bool) {
+		for {
+			word, err := g.GenerateWord(ctx)
+			if !yield(word, err) || err != nil {
+				return
+			}
+		}
+	}
+}
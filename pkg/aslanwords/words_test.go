@@ -0,0 +1,78 @@
+package aslanwords_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/carloscasalar/aslan-words/pkg/aslanwords"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWords_should_yield_words_until_the_loop_is_broken(t *testing.T) {
+	var words []aslanwords.Word
+	for word, err := range aslanwords.Words(context.Background(), aslanwords.WithNumberOfSyllables(2)) {
+		require.NoError(t, err)
+		words = append(words, word)
+		if len(words) == 25 {
+			break
+		}
+	}
+
+	require.Len(t, words, 25)
+	for _, word := range words {
+		assert.NotEmpty(t, word.String())
+	}
+}
+
+func TestWords_when_context_is_cancelled_it_should_yield_the_context_error_and_stop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var yieldedWords int
+	var lastErr error
+	for _, err := range aslanwords.Words(ctx) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		yieldedWords++
+		if yieldedWords == 5 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, 5, yieldedWords)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+}
+
+func TestWords_when_options_are_invalid_it_should_only_yield_the_error(t *testing.T) {
+	var errs []error
+	for _, err := range aslanwords.Words(context.Background(), aslanwords.WithNumberOfSyllables(0)) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.Error(t, errs[0])
+}
+
+func TestWords_when_called_with_the_same_seed_it_should_yield_the_same_words(t *testing.T) {
+	first := takeWords(t, aslanwords.Words(context.Background(), aslanwords.WithSeed(5)), 10)
+	second := takeWords(t, aslanwords.Words(context.Background(), aslanwords.WithSeed(5)), 10)
+
+	assert.Equal(t, first, second)
+}
+
+func takeWords(t *testing.T, words iter.Seq2[aslanwords.Word, error], n int) []string {
+	t.Helper()
+	taken := make([]string, 0, n)
+	for word, err := range words {
+		require.NoError(t, err)
+		taken = append(taken, word.String())
+		if len(taken) == n {
+			break
+		}
+	}
+	return taken
+}